@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+)
+
+// collapseDuplicates is the attachment recorded by CollapseDuplicates.
+type collapseDuplicates struct{}
+
+// CollapseDuplicates returns an error that collapses consecutive identical
+// annotations in its chain in the output of Error, ErrorStack and Details, as
+// Policy.CollapseDuplicates does for all errors, and records the location of
+// the CollapseDuplicates call, much like Trace. The annotations added to the
+// result are collapsed too. If err is nil, the result will be nil.
+//
+// For example, with an error annotated on each attempt of a retry loop:
+//
+//	err = errors.CollapseDuplicates(err)
+//	fmt.Println(err) // cannot deploy (x3): not found
+func CollapseDuplicates(err error) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: collapseDuplicates{},
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// collapsedMessage returns message with the number of annotations collapsed
+// into it.
+func collapsedMessage(message string, count int) string {
+	if count < 2 {
+		return message
+	}
+	return fmt.Sprintf("%s (x%d)", message, count)
+}
+
+// collapseFrames collapses each run of consecutive frames with the same
+// message, and the frames without a message between them, into the most
+// recent frame of the run, with the number of frames with the message added
+// to it.
+func collapseFrames(frames []Frame) []Frame {
+	var collapsed []Frame
+	for i := 0; i < len(frames); {
+		frame := frames[i]
+		count, end := 1, i+1
+		for j := end; frame.Message != "" && j < len(frames); j++ {
+			next := frames[j]
+			if next.Cause != "" || next.Remote != frame.Remote {
+				break
+			}
+			if next.Message == "" {
+				continue
+			}
+			if next.Message != frame.Message {
+				break
+			}
+			count, end = count+1, j+1
+		}
+		if count > 1 {
+			last := frames[end-1]
+			last.Message = collapsedMessage(frame.Message, count)
+			last.Cause = frame.Cause
+			last.Elapsed = 0
+			for _, f := range frames[i:end] {
+				last.Elapsed += f.Elapsed
+			}
+			frame = last
+		}
+		collapsed = append(collapsed, frame)
+		i = end
+	}
+	return collapsed
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type collapseSuite struct{}
+
+var _ = gc.Suite(&collapseSuite{})
+
+func retried(c *gc.C) (error, []string) {
+	err := errors.NotFoundf("unit")
+	locs := []string{errorLocationValue(c)}
+	for i := 0; i < 3; i++ {
+		err = errors.Annotate(err, "cannot deploy")
+		locs = append(locs, errorLocationValue(c))
+		err = errors.Trace(err)
+		locs = append(locs, errorLocationValue(c))
+	}
+	return err, locs
+}
+
+func (*collapseSuite) TestDisabled(c *gc.C) {
+	err, _ := retried(c)
+	c.Assert(err.Error(), gc.Equals, "cannot deploy: cannot deploy: cannot deploy: unit not found")
+	c.Assert(errors.Details(err), gc.Matches, `\[(\{[^}]*\} ?){7}\]`)
+}
+
+func (*collapseSuite) TestPolicy(c *gc.C) {
+	previous := errors.SetPolicy(errors.Policy{CollapseDuplicates: true})
+	defer errors.SetPolicy(previous)
+
+	err, locs := retried(c)
+	c.Assert(err.Error(), gc.Equals, "cannot deploy (x3): unit not found")
+	c.Assert(errors.ErrorStack(err), gc.Equals, ""+
+		locs[0]+": unit not found\n"+
+		locs[5]+": cannot deploy (x3)\n"+
+		locs[6]+": ")
+	c.Assert(errors.Details(err), gc.Equals, "[{"+locs[6]+": } {"+locs[5]+": cannot deploy (x3)} {"+locs[0]+": unit not found}]")
+}
+
+func (*collapseSuite) TestCollapseDuplicates(c *gc.C) {
+	err, locs := retried(c)
+	err = errors.CollapseDuplicates(err)
+	loc := errorLocationValue(c)
+	c.Assert(err.Error(), gc.Equals, "cannot deploy (x3): unit not found")
+	c.Assert(errors.ErrorStack(err), gc.Equals, ""+
+		locs[0]+": unit not found\n"+
+		locs[5]+": cannot deploy (x3)\n"+
+		locs[6]+": \n"+
+		loc+": ")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+
+	// Annotations added later are collapsed too.
+	err = errors.Annotate(err, "cannot deploy")
+	c.Assert(err.Error(), gc.Equals, "cannot deploy (x4): unit not found")
+	err = errors.Annotate(err, "cannot run")
+	c.Assert(err.Error(), gc.Equals, "cannot run: cannot deploy (x4): unit not found")
+}
+
+func (*collapseSuite) TestDistinctMessages(c *gc.C) {
+	err := errors.Annotate(errors.New("boom"), "a")
+	err = errors.Annotate(err, "b")
+	err = errors.Annotate(err, "a")
+	err = errors.CollapseDuplicates(err)
+	c.Assert(err.Error(), gc.Equals, "a: b: a: boom")
+}
+
+func (*collapseSuite) TestNewCause(c *gc.C) {
+	err := errors.Annotate(errors.New("boom"), "cannot deploy")
+	err = errors.Wrap(err, errors.New("cannot deploy"))
+	err = errors.CollapseDuplicates(err)
+	c.Assert(err.Error(), gc.Equals, "cannot deploy")
+	c.Assert(errors.Details(err), gc.Matches, `\[\{.*\} \{.*: cannot deploy\} \{.*: boom\}\]`)
+}
+
+func (*collapseSuite) TestNil(c *gc.C) {
+	c.Assert(errors.CollapseDuplicates(nil), gc.IsNil)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// ColorMode controls whether a Formatter colours its output with ANSI escape
+// sequences.
+type ColorMode int
+
+const (
+	// ColorNever renders plain text. This is the default, so that the
+	// output written to logs is unchanged.
+	ColorNever ColorMode = iota
+
+	// ColorAuto colours the output when writing to a terminal, unless the
+	// NO_COLOR environment variable is set to a non-empty value or the TERM
+	// environment variable is "dumb".
+	ColorAuto
+
+	// ColorAlways colours the output regardless of where it is written to
+	// and of the environment.
+	ColorAlways
+)
+
+// ANSI escape sequences of the styles used to colour the parts of a frame.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// partColors holds the style of each part of a frame.
+var partColors = map[framePart]string{
+	partTime:     ansiDim,
+	partRemote:   ansiMagenta,
+	partLocation: ansiCyan,
+	partMessage:  ansiBold,
+	partCause:    ansiRed,
+}
+
+// useColor reports whether output written to w is coloured in mode.
+func (mode ColorMode) useColor(w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+			return false
+		}
+		return isTerminal(w)
+	}
+	return false
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorStyle returns a style for Frame.render that colours each part of a
+// frame, with the text of any of kinds ending a message or cause highlighted.
+func colorStyle(kinds []string) func(framePart, string) string {
+	return func(part framePart, s string) string {
+		color := partColors[part]
+		if part != partMessage && part != partCause {
+			return color + s + ansiReset
+		}
+		kind := ""
+		for _, k := range kinds {
+			if len(k) > len(kind) && (s == k || strings.HasSuffix(s, " "+k)) {
+				kind = k
+			}
+		}
+		if kind == "" {
+			return color + s + ansiReset
+		}
+		text := s[:len(s)-len(kind)]
+		if text != "" {
+			text = color + text + ansiReset
+		}
+		return text + ansiYellow + kind + ansiReset
+	}
+}
+
+// WriteErrorStack writes the ErrorStack of err to w followed by a newline,
+// coloured if w is a terminal as with ColorAuto. This is intended for
+// showing errors to users in a terminal, such as before exiting a command:
+//
+//	if err := run(); err != nil {
+//	    errors.WriteErrorStack(os.Stderr, err)
+//	    os.Exit(1)
+//	}
+func WriteErrorStack(w io.Writer, err error) error {
+	if err := (Formatter{Color: ColorAuto}).Format(w, err); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"bytes"
+	"os"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type colorSuite struct{}
+
+var _ = gc.Suite(&colorSuite{})
+
+func (*colorSuite) TestColorAlways(c *gc.C) {
+	err := errors.NotFoundf("unit")
+	loc0 := errorLocationValue(c)
+	err = errors.Wrap(err, errors.New("new cause"))
+	loc1 := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	loc2 := errorLocationValue(c)
+
+	f := errors.Formatter{Color: errors.ColorAlways}
+	c.Assert(f.String(err), gc.Equals,
+		"\x1b[36m"+loc0+"\x1b[0m: \x1b[1munit \x1b[0m\x1b[33mnot found\x1b[0m\n"+
+			"\x1b[36m"+loc1+"\x1b[0m: \x1b[31mnew cause\x1b[0m\n"+
+			"\x1b[36m"+loc2+"\x1b[0m: \x1b[1mcannot deploy\x1b[0m")
+}
+
+func (*colorSuite) TestColorRemote(c *gc.C) {
+	err := errors.NewRemoteError(errors.Forbidden, errors.Origin{})
+	f := errors.Formatter{Color: errors.ColorAlways, OmitLocations: true}
+	c.Assert(f.String(err), gc.Equals, "\x1b[35mremote:\x1b[0m \x1b[33mforbidden\x1b[0m")
+}
+
+func (*colorSuite) TestColorAuto(c *gc.C) {
+	err := errors.Annotate(errors.NotFoundf("unit"), "cannot deploy")
+	var buf bytes.Buffer
+	c.Assert(errors.WriteErrorStack(&buf, err), gc.IsNil)
+	c.Assert(buf.String(), gc.Equals, errors.ErrorStack(err)+"\n")
+
+	f := errors.Formatter{Color: errors.ColorAuto}
+	c.Assert(f.String(err), gc.Equals, errors.ErrorStack(err))
+}
+
+func (*colorSuite) TestNoColor(c *gc.C) {
+	previous, set := os.LookupEnv("NO_COLOR")
+	os.Setenv("NO_COLOR", "1")
+	defer func() {
+		if set {
+			os.Setenv("NO_COLOR", previous)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+
+	err := errors.New("boom")
+	f := errors.Formatter{Color: errors.ColorAuto}
+	c.Assert(f.Format(os.Stdout, nil), gc.IsNil)
+	c.Assert(f.String(err), gc.Equals, errors.ErrorStack(err))
+
+	// Forcing colours overrides NO_COLOR.
+	f.Color = errors.ColorAlways
+	c.Assert(f.String(err), gc.Matches, "\x1b\\[36m.*")
+}
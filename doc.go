@@ -75,5 +75,9 @@ for the situation.
 This returns an error where the complete error stack is still available, and
 `errors.Cause()` will return the `NotFound` error.
 
+This package requires Go 1.21 or later, for its integration with the log/slog
+package of the standard library. Earlier versions of this package support Go
+1.18.
+
 */
 package errors
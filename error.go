@@ -4,8 +4,10 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // Err holds a description of an error along with information about
@@ -17,6 +19,12 @@ type Err struct {
 	// message holds an annotation of the error.
 	message string
 
+	// format and args hold the format string and arguments message was
+	// created from, if any, so that it can be rendered again without
+	// redacting secrets.
+	format string
+	args   []interface{}
+
 	// cause holds the cause of the error as returned
 	// by the Cause method.
 	cause error
@@ -30,6 +38,14 @@ type Err struct {
 
 	// line is the line number the error was created on inside of function
 	line int
+
+	// time is when the error was created, if recorded by the policy.
+	time time.Time
+
+	// attachment holds an additional value carried by this layer of the
+	// error stack, such as an ErrorInfo. It does not contribute to the
+	// error message.
+	attachment interface{}
 }
 
 // Locationer is an interface that represents a certain class of errors that
@@ -40,6 +56,14 @@ type Locationer interface {
 	Location() (function string, line int)
 }
 
+// Timestamper is an interface that represents a certain class of errors that
+// record the time at which they were created, when enabled by Policy.
+type Timestamper interface {
+	// Timestamp returns the time the error was created, or the zero time
+	// if it was not recorded.
+	Timestamp() time.Time
+}
+
 // locationError is the internal implementation of the Locationer interface.
 type locationError struct {
 	error
@@ -50,6 +74,9 @@ type locationError struct {
 
 	// line is the line number the error was created on inside of function
 	line int
+
+	// time is when the error was created, if recorded by the policy.
+	time time.Time
 }
 
 // newLocationError constructs a new Locationer error from the supplied error
@@ -58,15 +85,13 @@ type locationError struct {
 func newLocationError(err error, callDepth int) *locationError {
 	le := &locationError{error: err}
 	le.function, le.line = getLocation(callDepth + 1)
+	le.time = recordTime()
 	return le
 }
 
 // Error implementes the error interface.
 func (l *locationError) Error() string {
-	if l.error == nil {
-		return ""
-	}
-	return l.error.Error()
+	return limitLength(l.renderText(renderOptions(l)))
 }
 
 // *locationError implements Locationer.Location interface
@@ -74,10 +99,94 @@ func (l *locationError) Location() (string, int) {
 	return l.function, l.line
 }
 
+// Timestamp implements Timestamper.
+func (l *locationError) Timestamp() time.Time {
+	return l.time
+}
+
 func (l *locationError) Unwrap() error {
 	return l.error
 }
 
+// renderText implements textRenderer.
+func (l *locationError) renderText(o textOptions) string {
+	if l.error == nil {
+		return ""
+	}
+	return errorString(l.error, o)
+}
+
+// Message implements wrapper. A locationError takes the place of the error
+// it holds in the annotation stack, with its own location: if that error is
+// part of an annotation stack, such as an Err, this is its message, otherwise
+// it is the error string of the originating error.
+func (l *locationError) Message() string {
+	return l.renderMessage(textOptions{})
+}
+
+// renderMessage implements messageRenderer.
+func (l *locationError) renderMessage(o textOptions) string {
+	message, _ := stackLayer(l.error, o)
+	return message
+}
+
+// Underlying implements wrapper.
+func (l *locationError) Underlying() error {
+	_, underlying := stackLayer(l.error, textOptions{})
+	return underlying
+}
+
+// stackCause implements stackCauser.
+func (l *locationError) stackCause() error {
+	return stackCause(l.error)
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (l *locationError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, l)
+}
+
+// stackLayer returns the message rendered with o and the underlying error of
+// err as an entry of an annotation stack. If err is not part of an annotation
+// stack, the message is its error string and it has no underlying error.
+func stackLayer(err error, o textOptions) (string, error) {
+	switch e := err.(type) {
+	case nil:
+		return "", nil
+	case *RemoteError:
+		// Keep the remote stack.
+		return "", e
+	case wrapper:
+		message := e.Message()
+		if r, ok := e.(messageRenderer); ok {
+			message = r.renderMessage(o)
+		}
+		return message, e.Underlying()
+	}
+	return errorString(err, o), nil
+}
+
+// stackCauser is implemented by the errors that take the place of the error
+// they hold in the annotation stack, such as locationError. They do not
+// implement causer, so that Cause returns them rather than the cause of the
+// error they hold, but the entry they render shows that cause.
+type stackCauser interface {
+	stackCause() error
+}
+
+// stackCause returns the cause of err as an entry of an annotation stack, or
+// nil if it does not have one.
+func stackCause(err error) error {
+	switch e := err.(type) {
+	case stackCauser:
+		return e.stackCause()
+	case causer:
+		return e.Cause()
+	}
+	return nil
+}
+
 // NewErr is used to return an Err for the purpose of embedding in other
 // structures.  The location is not specified, and needs to be set with a call
 // to SetLocation.
@@ -94,9 +203,11 @@ func (l *locationError) Unwrap() error {
 //         return err
 //     }
 func NewErr(format string, args ...interface{}) Err {
-	return Err{
+	err := Err{
 		message: fmt.Sprintf(format, args...),
 	}
+	err.format, err.args = retainedFormat(format, args)
+	return err
 }
 
 // NewErrWithCause is used to return an Err with cause by other error for the purpose of embedding in other
@@ -115,11 +226,13 @@ func NewErr(format string, args ...interface{}) Err {
 //         return err
 //     })
 func NewErrWithCause(other error, format string, args ...interface{}) Err {
-	return Err{
+	err := Err{
 		message:  fmt.Sprintf(format, args...),
 		cause:    Cause(other),
 		previous: other,
 	}
+	err.format, err.args = retainedFormat(format, args)
+	return err
 }
 
 // Location returns the  package path-qualified function name and line of where
@@ -128,6 +241,12 @@ func (e *Err) Location() (function string, line int) {
 	return e.function, e.line
 }
 
+// Timestamp returns the time the error was most recently created or
+// annotated, or the zero time if recording times is not enabled by Policy.
+func (e *Err) Timestamp() time.Time {
+	return e.time
+}
+
 // Underlying returns the previous error in the error stack, if any. A client
 // should not ever really call this method.  It is used to build the error
 // stack and should not be introspected by client calls.  Or more
@@ -156,19 +275,56 @@ func (e *Err) Message() string {
 
 // Error implements error.Error.
 func (e *Err) Error() string {
+	return limitLength(e.renderText(renderOptions(e)))
+}
+
+// renderText implements textRenderer.
+func (e *Err) renderText(o textOptions) string {
+	err := e.next()
+	message := e.renderMessage(o)
+	if o.collapse && message != "" {
+		// Skip the consecutive annotations with the same message, and the
+		// tracing between them, counting the annotations.
+		count := 1
+		for {
+			inner, ok := err.(*Err)
+			if !ok {
+				break
+			}
+			innerMessage := inner.renderMessage(o)
+			if innerMessage != "" && innerMessage != message {
+				break
+			}
+			if innerMessage == message {
+				count++
+			}
+			err = inner.next()
+		}
+		message = collapsedMessage(message, count)
+	}
+	switch {
+	case err == nil:
+		return message
+	case message == "":
+		return errorString(err, o)
+	}
+	return joinMessage(message, err, o)
+}
+
+// next returns the error rendered after the message of e by Error.
+func (e *Err) next() error {
 	// We want to walk up the stack of errors showing the annotations
 	// as long as the cause is the same.
 	err := e.previous
 	if !sameError(Cause(err), e.cause) && e.cause != nil {
 		err = e.cause
 	}
-	switch {
-	case err == nil:
-		return e.message
-	case e.message == "":
-		return err.Error()
-	}
-	return fmt.Sprintf("%s: %v", e.message, err)
+	return err
+}
+
+// renderMessage implements messageRenderer.
+func (e *Err) renderMessage(o textOptions) string {
+	return o.message(e.message, e.format, e.args)
 }
 
 // Format implements fmt.Formatter
@@ -200,12 +356,53 @@ func (e *Err) Format(s fmt.State, verb rune) {
 // helper for Format
 type unformatter Err
 
+// formatError implements fmt.Formatter for the errors of this package, in the
+// same way as Err.Format.
+func formatError(s fmt.State, verb rune, err error) {
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			fmt.Fprintf(s, "%s", ErrorStack(err))
+			return
+		case s.Flag('#'):
+			// The error types of this package implement Format with pointer
+			// receivers, so the value they point to is printed without
+			// recursion.
+			if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && !v.IsNil() {
+				fmt.Fprintf(s, "&%#v", v.Elem().Interface())
+				return
+			}
+		}
+		fallthrough
+	case 's':
+		fmt.Fprintf(s, "%s", err.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", err.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, err, err.Error())
+	}
+}
+
 func (unformatter) Format() { /* break the fmt.Formatter interface */ }
 
 // SetLocation records the package path-qualified function name of the error at
-// callDepth stack frames above the call.
+// callDepth stack frames above the call. The current time is also recorded if
+// enabled by Policy.
 func (e *Err) SetLocation(callDepth int) {
 	e.function, e.line = getLocation(callDepth + 1)
+	e.time = recordTime()
+}
+
+// recordTime returns the current time if recording times is enabled by
+// Policy, or the zero time otherwise.
+func recordTime() time.Time {
+	if !CurrentPolicy().RecordTime {
+		return time.Time{}
+	}
+	// Strip the monotonic clock reading so that elapsed times are the same
+	// after the error has been encoded and decoded.
+	return time.Now().Round(0)
 }
 
 // StackTrace returns one string for each location recorded in the stack of
@@ -220,6 +417,37 @@ func sameError(e1, e2 error) bool {
 	return reflect.DeepEqual(e1, e2)
 }
 
+// attached walks the error chain starting at err and returns the first
+// attachment of type T, so that attachments on outer errors take precedence
+// over those on inner ones.
+func attached[T any](err error) (T, bool) {
+	for err != nil {
+		if e, ok := err.(*Err); ok {
+			if v, ok := e.attachment.(T); ok {
+				return v, true
+			}
+		}
+		err = stderrors.Unwrap(err)
+	}
+	var zero T
+	return zero, false
+}
+
+// allAttached walks the error chain starting at err and returns every
+// attachment of type T, ordered from the outermost error to the innermost.
+func allAttached[T any](err error) []T {
+	var values []T
+	for err != nil {
+		if e, ok := err.(*Err); ok {
+			if v, ok := e.attachment.(T); ok {
+				values = append(values, v)
+			}
+		}
+		err = stderrors.Unwrap(err)
+	}
+	return values
+}
+
 // Unwrap is a synonym for Underlying, which allows Err to be used with the
 // Unwrap, Is and As functions in Go's standard `errors` library.
 func (e *Err) Unwrap() error {
@@ -0,0 +1,77 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+// ErrorInfo describes the cause of an error in a stable, machine-readable
+// form that is suitable for returning to API consumers. Unlike the message of
+// an error, the values of an ErrorInfo are not expected to change between
+// releases.
+type ErrorInfo struct {
+	// Reason is a short, constant identifier for the cause of the error,
+	// such as "MODEL_NOT_FOUND".
+	Reason string
+
+	// Domain is the logical grouping to which Reason belongs, typically the
+	// name of the service or component that generated the error, such as
+	// "juju.controller".
+	Domain string
+
+	// Metadata holds additional structured details about the error, such as
+	// the name of the model that could not be found.
+	Metadata map[string]string
+}
+
+// WithErrorInfo attaches info to err and records the location of the
+// WithErrorInfo call, much like Trace. The message, Cause and error types of
+// err are unchanged, so the result continues to satisfy Is for any kind that
+// err satisfies. If err is nil, the result will be nil.
+//
+// For example:
+//
+//	if err := st.Model(uuid); errors.Is(err, errors.NotFound) {
+//	    return errors.WithErrorInfo(err, errors.ErrorInfo{
+//	        Reason:   "MODEL_NOT_FOUND",
+//	        Domain:   "juju.controller",
+//	        Metadata: map[string]string{"model-uuid": uuid},
+//	    })
+//	}
+func WithErrorInfo(err error, info ErrorInfo) error {
+	if err == nil {
+		return nil
+	}
+	info.Metadata = copyMetadata(info.Metadata)
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: info,
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// Info returns the ErrorInfo attached to err by the outermost call to
+// WithErrorInfo in its chain. The ErrorInfo is found even if err has
+// since been traced or annotated. If no ErrorInfo has been attached, Info
+// returns false.
+func Info(err error) (ErrorInfo, bool) {
+	info, ok := attached[ErrorInfo](err)
+	if !ok {
+		return ErrorInfo{}, false
+	}
+	info.Metadata = copyMetadata(info.Metadata)
+	return info, true
+}
+
+// copyMetadata returns a copy of m so that callers cannot mutate metadata
+// that has already been attached to an error.
+func copyMetadata(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
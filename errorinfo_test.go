@@ -0,0 +1,80 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	stderrors "errors"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type errorInfoSuite struct{}
+
+var _ = gc.Suite(&errorInfoSuite{})
+
+func (*errorInfoSuite) TestWithErrorInfoNil(c *gc.C) {
+	c.Assert(errors.WithErrorInfo(nil, errors.ErrorInfo{Reason: "NOPE"}), gc.IsNil)
+}
+
+func (*errorInfoSuite) TestWithErrorInfo(c *gc.C) {
+	first := errors.NotFoundf("model %q", "foo")
+	info := errors.ErrorInfo{
+		Reason:   "MODEL_NOT_FOUND",
+		Domain:   "juju.controller",
+		Metadata: map[string]string{"model": "foo"},
+	}
+	err := errors.WithErrorInfo(first, info)
+	loc := errorLocationValue(c)
+
+	c.Assert(err.Error(), gc.Equals, `model "foo" not found`)
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Details(err), Contains, loc)
+
+	obtained, ok := errors.Info(err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(obtained, gc.DeepEquals, info)
+}
+
+func (*errorInfoSuite) TestInfoSurvivesTraceAndAnnotate(c *gc.C) {
+	err := errors.WithErrorInfo(errors.New("boom"), errors.ErrorInfo{Reason: "BOOM"})
+	err = errors.Trace(err)
+	err = errors.Annotate(err, "cannot frombulate")
+
+	c.Assert(err.Error(), gc.Equals, "cannot frombulate: boom")
+	info, ok := errors.Info(err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(info.Reason, gc.Equals, "BOOM")
+}
+
+func (*errorInfoSuite) TestOuterInfoTakesPrecedence(c *gc.C) {
+	err := errors.WithErrorInfo(errors.New("boom"), errors.ErrorInfo{Reason: "INNER"})
+	err = errors.Annotate(err, "context")
+	err = errors.WithErrorInfo(err, errors.ErrorInfo{Reason: "OUTER"})
+
+	info, ok := errors.Info(err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(info.Reason, gc.Equals, "OUTER")
+}
+
+func (*errorInfoSuite) TestInfoMissing(c *gc.C) {
+	_, ok := errors.Info(errors.Trace(stderrors.New("plain")))
+	c.Assert(ok, gc.Equals, false)
+	_, ok = errors.Info(nil)
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (*errorInfoSuite) TestMetadataIsCopied(c *gc.C) {
+	metadata := map[string]string{"model": "foo"}
+	err := errors.WithErrorInfo(errors.New("boom"), errors.ErrorInfo{Metadata: metadata})
+	metadata["model"] = "bar"
+
+	info, _ := errors.Info(err)
+	c.Assert(info.Metadata["model"], gc.Equals, "foo")
+	info.Metadata["model"] = "baz"
+
+	info, _ = errors.Info(err)
+	c.Assert(info.Metadata["model"], gc.Equals, "foo")
+}
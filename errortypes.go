@@ -5,9 +5,9 @@ package errors
 
 import (
 	"errors"
-	stderror "errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // a ConstError is a prototype for a certain type of error
@@ -18,6 +18,11 @@ func (e ConstError) Error() string {
 	return string(e)
 }
 
+// renderText implements textRenderer.
+func (e ConstError) renderText(o textOptions) string {
+	return o.kind(e)
+}
+
 // Different types of errors
 const (
 	// Timeout represents an error on timeout.
@@ -55,6 +60,9 @@ const (
 	// NotYetAvailable is the error returned when a resource is not yet available
 	// but it might be in the future.
 	NotYetAvailable = ConstError("not yet available")
+	// Panic represents an error recovered from a panic by Recover or
+	// DeferredRecover.
+	Panic = ConstError("panic")
 )
 
 // errWithType is an Err bundled with its error type (a ConstError)
@@ -76,14 +84,155 @@ func (e *errWithType) Unwrap() error {
 	return e.error
 }
 
+// Error implements error, returning the error string of the error it holds.
+func (e *errWithType) Error() string {
+	return limitLength(e.renderText(renderOptions(e)))
+}
+
+// renderText implements textRenderer.
+func (e *errWithType) renderText(o textOptions) string {
+	return errorString(e.error, o)
+}
+
+// Message implements wrapper. An errWithType takes the place of the error it
+// holds in the annotation stack, as with locationError.
+func (e *errWithType) Message() string {
+	return e.renderMessage(textOptions{})
+}
+
+// renderMessage implements messageRenderer.
+func (e *errWithType) renderMessage(o textOptions) string {
+	message, _ := stackLayer(e.error, o)
+	return message
+}
+
+// Underlying implements wrapper.
+func (e *errWithType) Underlying() error {
+	_, underlying := stackLayer(e.error, textOptions{})
+	return underlying
+}
+
+// stackCause implements stackCauser.
+func (e *errWithType) stackCause() error {
+	return stackCause(e.error)
+}
+
+// Location implements Locationer, returning the location of the error it
+// holds, if any.
+func (e *errWithType) Location() (string, int) {
+	if l, ok := e.error.(Locationer); ok {
+		return l.Location()
+	}
+	return "", 0
+}
+
+// Timestamp implements Timestamper, returning the time the error it holds
+// was created, if recorded.
+func (e *errWithType) Timestamp() time.Time {
+	if t, ok := e.error.(Timestamper); ok {
+		return t.Timestamp()
+	}
+	return time.Time{}
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (e *errWithType) Format(s fmt.State, verb rune) {
+	formatError(s, verb, e)
+}
+
+// errorKinds returns the error types (ConstErrors) that err satisfies through
+// its chain, ordered from the outermost to the innermost and without
+// duplicates.
+func errorKinds(err error) []ConstError {
+	var kinds []ConstError
+	add := func(kind ConstError) {
+		for _, k := range kinds {
+			if k == kind {
+				return
+			}
+		}
+		kinds = append(kinds, kind)
+	}
+	var walk func(error)
+	walk = func(err error) {
+		for err != nil {
+			switch e := err.(type) {
+			case ConstError:
+				add(e)
+			case *errWithType:
+				add(e.errType)
+			case *PanicError:
+				add(Panic)
+			}
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, err := range multi.Unwrap() {
+					walk(err)
+				}
+				return
+			}
+			err = errors.Unwrap(err)
+		}
+	}
+	walk(err)
+	return kinds
+}
+
 func wrapErrorWithMsg(err error, msg string) error {
 	if err == nil {
-		return stderror.New(msg)
+		return &messageError{message: msg}
 	}
 	if msg == "" {
 		return err
 	}
-	return fmt.Errorf("%s: %w", msg, err)
+	return &messageError{message: msg, err: err}
+}
+
+// messageError is an error annotated with a message, rendered as
+// "message: err", or just as the message if there is no error.
+type messageError struct {
+	message string
+	err     error
+}
+
+// Error implements error.
+func (m *messageError) Error() string {
+	return limitLength(m.renderText(textOptions{}))
+}
+
+// renderText implements textRenderer.
+func (m *messageError) renderText(o textOptions) string {
+	message := o.message(m.message, "", nil)
+	if m.err == nil {
+		return message
+	}
+	return joinMessage(message, m.err, o)
+}
+
+// Unwrap returns the error annotated by the message.
+func (m *messageError) Unwrap() error {
+	return m.err
+}
+
+// Message implements wrapper.
+func (m *messageError) Message() string {
+	return m.message
+}
+
+// renderMessage implements messageRenderer.
+func (m *messageError) renderMessage(o textOptions) string {
+	return o.message(m.message, "", nil)
+}
+
+// Underlying implements wrapper.
+func (m *messageError) Underlying() error {
+	return m.err
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (m *messageError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, m)
 }
 
 func makeWrappedConstError(err error, format string, args ...interface{}) error {
@@ -91,7 +240,53 @@ func makeWrappedConstError(err error, format string, args ...interface{}) error
 	if err.Error() == "" || errors.Is(err, &fmtNoop{}) {
 		separator = ""
 	}
-	return fmt.Errorf(strings.Join([]string{format, "%w"}, separator), append(args, err)...)
+	f := &formattedError{
+		error:     fmt.Errorf(strings.Join([]string{format, "%w"}, separator), append(args, err)...),
+		separator: separator,
+		wrapped:   err,
+	}
+	f.format, f.args = retainedFormat(format, args)
+	return f
+}
+
+// formattedError is an error formatted around a wrapped error with
+// fmt.Errorf. It keeps the format string and arguments so that the message
+// can be rendered again, such as without redacting secrets or in another
+// language.
+type formattedError struct {
+	error
+	format    string
+	separator string
+	args      []interface{}
+	wrapped   error
+}
+
+// renderText implements textRenderer.
+func (f *formattedError) renderText(o textOptions) string {
+	if o.isPlain() {
+		return f.Error()
+	}
+	wrapped := f.wrapped
+	if kind, ok := wrapped.(ConstError); ok {
+		wrapped = ConstError(o.kind(kind))
+	}
+	format, _ := o.translate(f.format)
+	args := f.args
+	if o.reveal {
+		args = reveal(args)
+	}
+	return fmt.Errorf(strings.Join([]string{format, "%w"}, f.separator), append(args, wrapped)...).Error()
+}
+
+// Unwrap returns the error wrapped by the format string.
+func (f *formattedError) Unwrap() error {
+	return f.wrapped
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (f *formattedError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, f)
 }
 
 // WithType is responsible for annotating an already existing error so that it
@@ -6,6 +6,7 @@ package errors_test
 import (
 	stderrors "errors"
 	"fmt"
+	"regexp"
 
 	"github.com/juju/errors"
 	gc "gopkg.in/check.v1"
@@ -193,3 +194,72 @@ func (*errorTypeSuite) TestWithType(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "yes")
 	c.Assert(errors.Is(err, myErr2), gc.Equals, false)
 }
+
+func (*errorTypeSuite) TestCause(c *gc.C) {
+	err := errors.New("boom")
+	kind := errors.ConstError("custom")
+
+	notFound := errors.NewNotFound(errors.Trace(err), "")
+	c.Assert(errors.Is(errors.Cause(notFound), errors.NotFound), gc.Equals, true)
+	withType := errors.WithType(errors.Trace(err), kind)
+	c.Assert(errors.Cause(withType), gc.Equals, withType)
+	located := errors.SetLocation(errors.Trace(err), 0)
+	c.Assert(errors.Cause(located), gc.Equals, located)
+
+	// WithType does not add an entry to the stack.
+	err = errors.Trace(err)
+	loc0 := errorLocationValue(c)
+	err = errors.Annotate(errors.WithType(err, kind), "cannot deploy")
+	loc1 := errorLocationValue(c)
+	c.Assert(errors.ErrorStack(err), gc.Matches, `.*: boom\n`+regexp.QuoteMeta(loc0)+": \n"+regexp.QuoteMeta(loc1)+": cannot deploy")
+}
+
+func (*errorTypeSuite) TestErrorStack(c *gc.C) {
+	err := errors.NotFoundf("unit %q", "mysql/0")
+	loc0 := errorLocationValue(c)
+	c.Assert(errors.ErrorStack(err), gc.Equals, loc0+`: unit "mysql/0" not found`)
+	c.Assert(fmt.Sprintf("%+v", err), gc.Equals, errors.ErrorStack(err))
+	c.Assert(fmt.Sprintf("%v", err), gc.Equals, `unit "mysql/0" not found`)
+	c.Assert(fmt.Sprintf("%q", err), gc.Equals, `"unit \"mysql/0\" not found"`)
+
+	err = errors.Trace(err)
+	loc1 := errorLocationValue(c)
+	err = errors.NewNotValid(err, "bad unit")
+	loc2 := errorLocationValue(c)
+	err = errors.WithType(err, errors.ConstError("custom"))
+	err = errors.Annotate(err, "cannot deploy")
+	loc3 := errorLocationValue(c)
+
+	expected := loc0 + `: unit "mysql/0" not found` + "\n" +
+		loc1 + ": \n" +
+		loc2 + ": bad unit\n" +
+		loc3 + ": cannot deploy"
+	c.Assert(errors.ErrorStack(err), gc.Equals, expected)
+	c.Assert(err.Error(), gc.Equals, `cannot deploy: bad unit: unit "mysql/0" not found`)
+	c.Assert(errors.Details(err), gc.Equals, "[{"+loc3+": cannot deploy} {"+loc2+": bad unit} {"+loc1+": } {"+loc0+`: unit "mysql/0" not found}]`)
+
+	withType := errors.Unwrap(err)
+	c.Assert(fmt.Sprintf("%+v", withType), gc.Equals, loc0+`: unit "mysql/0" not found`+"\n"+loc1+": \n"+loc2+": bad unit")
+	c.Assert(fmt.Sprintf("%s", withType), gc.Equals, `bad unit: unit "mysql/0" not found`)
+}
+
+func (*errorTypeSuite) TestErrorStackWrapConstructor(c *gc.C) {
+	err := errors.NewForbidden(nil, "go away")
+	loc := errorLocationValue(c)
+	c.Assert(errors.ErrorStack(err), gc.Equals, loc+": go away")
+	c.Assert(fmt.Sprintf("%+v", err), gc.Equals, loc+": go away")
+
+	err = errors.NewTimeout(stderrors.New("dial failed"), "connecting")
+	loc = errorLocationValue(c)
+	c.Assert(errors.ErrorStack(err), gc.Equals, "dial failed\n"+loc+": connecting")
+
+	var locationer errors.Locationer
+	c.Assert(stderrors.As(err, &locationer), gc.Equals, true)
+	_, implements := err.(errors.Locationer)
+	c.Assert(implements, gc.Equals, true)
+}
+
+func (*errorTypeSuite) TestGoSyntax(c *gc.C) {
+	err := errors.WithType(stderrors.New("boom"), errors.ConstError("custom"))
+	c.Assert(fmt.Sprintf("%#v", err), gc.Matches, `&errors.errWithType\{error:\(\*errors.errorString\)\(0x[0-9a-f]+\), errType:"custom"\}`)
+}
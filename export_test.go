@@ -0,0 +1,54 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+// UnregisterErrorType removes the error type registered with name, so that
+// tests registering error types can be run repeatedly.
+func UnregisterErrorType(name string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	rt, ok := registryByName[name]
+	if !ok {
+		return
+	}
+	delete(registryByName, name)
+	for t, registered := range registryByType {
+		if registered == rt {
+			delete(registryByType, t)
+		}
+	}
+}
+
+// UnregisterKind removes kind from the kinds registered with RegisterKind,
+// so that tests registering kinds can be run repeatedly.
+func UnregisterKind(kind ConstError) {
+	kindsMutex.Lock()
+	defer kindsMutex.Unlock()
+	delete(kindsByName, kindName(string(kind)))
+	for i, k := range kinds {
+		if k == kind {
+			kinds = append(kinds[:i:i], kinds[i+1:]...)
+			break
+		}
+	}
+}
+
+// SetUnrecoverable replaces the errors registered with RegisterUnrecoverable
+// with targets and returns the previous ones, so that tests registering them
+// can restore the list.
+func SetUnrecoverable(targets []error) []error {
+	unrecoverableMutex.Lock()
+	defer unrecoverableMutex.Unlock()
+	previous := unrecoverable
+	unrecoverable = append([]error(nil), targets...)
+	return previous
+}
+
+// UnregisterHints removes the default hints registered for kind with
+// RegisterHint, so that tests registering hints do not affect other tests.
+func UnregisterHints(kind ConstError) {
+	kindHintsMutex.Lock()
+	defer kindHintsMutex.Unlock()
+	delete(kindHints, kind)
+}
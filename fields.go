@@ -0,0 +1,97 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+// badKey is the key used for a value passed to With that has no valid key,
+// matching the convention used by log/slog.
+const badKey = "!BADKEY"
+
+// Field is a key-value pair attached to an error with With.
+type Field struct {
+	// Key is the name of the field.
+	Key string
+
+	// Value is the value of the field recorded by the outermost call to With
+	// that set Key.
+	Value interface{}
+
+	// Shadowed holds the values of Key recorded by inner calls to With which
+	// have been overridden by Value, ordered from the most recent to the
+	// oldest.
+	Shadowed []interface{}
+}
+
+// fieldSet is the attachment recorded by With.
+type fieldSet []Field
+
+// With attaches key-value fields to err and records the location of the With
+// call, much like Trace. The keyvals are alternating keys and values; keys
+// must be strings. A value that is not preceded by a string key is recorded
+// under the key "!BADKEY", as log/slog does. The message and Cause of err are
+// unchanged. If err is nil, the result will be nil.
+//
+// For example:
+//
+//	if err := deploy(unit); err != nil {
+//	    return errors.With(err, "unit", unit.Name(), "attempt", attempt)
+//	}
+func With(err error, keyvals ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: makeFieldSet(keyvals),
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+func makeFieldSet(keyvals []interface{}) fieldSet {
+	var fields fieldSet
+	for len(keyvals) > 0 {
+		key, ok := keyvals[0].(string)
+		if !ok || len(keyvals) == 1 {
+			fields = append(fields, Field{Key: badKey, Value: keyvals[0]})
+			keyvals = keyvals[1:]
+			continue
+		}
+		fields = append(fields, Field{Key: key, Value: keyvals[1]})
+		keyvals = keyvals[2:]
+	}
+	return fields
+}
+
+// Fields returns the fields attached to err by every call to With in its
+// chain, merged into a single list. When the same key has been set more than
+// once, the most recently set value is used and the values it overrides are
+// available from Field.Shadowed. Fields are ordered by where their key first
+// appears, starting from the innermost error, so that the result is stable as
+// more context is added. Values without a valid key are not merged: each is a
+// separate field with the key "!BADKEY".
+func Fields(err error) []Field {
+	sets := allAttached[fieldSet](err)
+	var fields []Field
+	index := make(map[string]int)
+	for i := len(sets) - 1; i >= 0; i-- {
+		for _, f := range sets[i] {
+			if f.Key == badKey {
+				// Values without a valid key do not override each other.
+				fields = append(fields, f)
+				continue
+			}
+			pos, ok := index[f.Key]
+			if !ok {
+				index[f.Key] = len(fields)
+				fields = append(fields, Field{Key: f.Key, Value: f.Value})
+				continue
+			}
+			existing := &fields[pos]
+			existing.Shadowed = append([]interface{}{existing.Value}, existing.Shadowed...)
+			existing.Value = f.Value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type fieldsSuite struct{}
+
+var _ = gc.Suite(&fieldsSuite{})
+
+func (*fieldsSuite) TestWithNil(c *gc.C) {
+	c.Assert(errors.With(nil, "unit", "mysql/0"), gc.IsNil)
+}
+
+func (*fieldsSuite) TestWith(c *gc.C) {
+	first := errors.NotFoundf("unit")
+	err := errors.With(first, "unit", "mysql/0", "attempt", 2)
+	loc := errorLocationValue(c)
+
+	c.Assert(err.Error(), gc.Equals, "unit not found")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Details(err), Contains, loc)
+	c.Assert(errors.Fields(err), gc.DeepEquals, []errors.Field{
+		{Key: "unit", Value: "mysql/0"},
+		{Key: "attempt", Value: 2},
+	})
+}
+
+func (*fieldsSuite) TestFieldsMerged(c *gc.C) {
+	err := errors.With(errors.New("boom"), "unit", "mysql/0", "attempt", 1)
+	err = errors.Annotate(err, "cannot deploy")
+	err = errors.With(err, "attempt", 2, "model", "default")
+	err = errors.Trace(err)
+	err = errors.With(err, "attempt", 3)
+
+	c.Assert(err.Error(), gc.Equals, "cannot deploy: boom")
+	c.Assert(errors.Fields(err), gc.DeepEquals, []errors.Field{
+		{Key: "unit", Value: "mysql/0"},
+		{Key: "attempt", Value: 3, Shadowed: []interface{}{2, 1}},
+		{Key: "model", Value: "default"},
+	})
+}
+
+func (*fieldsSuite) TestFieldsBadKeys(c *gc.C) {
+	err := errors.With(errors.New("boom"), 42, "unit", "mysql/0", "dangling")
+	err = errors.With(err, true)
+	c.Assert(errors.Fields(err), gc.DeepEquals, []errors.Field{
+		{Key: "!BADKEY", Value: 42},
+		{Key: "unit", Value: "mysql/0"},
+		{Key: "!BADKEY", Value: "dangling"},
+		{Key: "!BADKEY", Value: true},
+	})
+}
+
+func (*fieldsSuite) TestFieldsNone(c *gc.C) {
+	c.Assert(errors.Fields(nil), gc.HasLen, 0)
+	c.Assert(errors.Fields(errors.New("boom")), gc.HasLen, 0)
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Formatter renders the annotation stack of an error, as described by Frames,
+// with configurable options. The zero Formatter renders the same output as
+// ErrorStack, and Details renders the output of:
+//
+//	errors.Formatter{OneLine: true, NewestFirst: true, OmitCauses: true, OmitTimes: true}
+//
+// In multi-line output, the errors held by a MultiError, such as one returned
+// by Group.Wait, are rendered as a tree below its entry, each with the same
+// options.
+type Formatter struct {
+	// OneLine renders the stack on a single line in the format of Details,
+	// rather than one entry per line in the format of ErrorStack.
+	OneLine bool
+
+	// OmitLocations omits the function and line number of each entry.
+	OmitLocations bool
+
+	// OmitCauses omits the error string of the new cause introduced by an
+	// entry, such as by a call to Wrap.
+	OmitCauses bool
+
+	// OmitTimes omits the time of each entry, when recording times is
+	// enabled by Policy.
+	OmitTimes bool
+
+	// NewestFirst renders the most recent entry first, rather than the
+	// originating error first.
+	NewestFirst bool
+
+	// MaxDepth, if positive, is the maximum number of entries rendered. The
+	// first MaxDepth entries in the rendering order are rendered, followed by
+	// an entry stating the number of entries omitted.
+	MaxDepth int
+
+	// MaxMessageLength, if positive, is the maximum number of characters of
+	// the message and the cause of each entry. Longer texts are truncated and
+	// end with "...".
+	MaxMessageLength int
+
+	// Indent is written at the start of each line of multi-line output.
+	Indent string
+
+	// OmitPanicStacks omits the stack of the goroutine that panicked, which
+	// follows the entries in multi-line output when the chain of the error
+	// holds a PanicError, such as one returned by Recover.
+	OmitPanicStacks bool
+
+	// Color controls whether the output is coloured, with the locations,
+	// annotations, kinds and the causes introduced by each entry in distinct
+	// colours. By default, the output is plain text.
+	Color ColorMode
+}
+
+// defaultFormatter renders ErrorStack.
+var defaultFormatter = Formatter{}
+
+// detailsFormatter renders Details.
+var detailsFormatter = Formatter{OneLine: true, NewestFirst: true, OmitCauses: true, OmitTimes: true}
+
+// Format writes the annotation stack of err to w, returning any error from
+// w. If a trace context has been attached to err with WithTraceContext, its
+// identifiers are written first, as a header line in multi-line output or
+// before the stack in one-line output.
+func (f Formatter) Format(w io.Writer, err error) error {
+	return f.format(w, err, renderOptions(err))
+}
+
+// String returns the annotation stack of err, as written by Format.
+func (f Formatter) String(err error) string {
+	var b strings.Builder
+	_ = f.Format(&b, err)
+	return b.String()
+}
+
+// format implements Format, rendering messages with the given text options.
+func (f Formatter) format(w io.Writer, err error, o textOptions) error {
+	fw := &formatWriter{w: w}
+	if err == nil {
+		if f.OneLine {
+			fw.write("[]")
+		}
+		return fw.err
+	}
+
+	frames := frames(err, o)
+	multi, _ := stackRoot(err).(*MultiError)
+	if o.collapse {
+		frames = collapseFrames(frames)
+	}
+	// multiIndex is the index of the entry of multi, the originating error.
+	multiIndex := 0
+	if f.NewestFirst {
+		multiIndex = len(frames) - 1
+		for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+			frames[i], frames[j] = frames[j], frames[i]
+		}
+	}
+	omitted := 0
+	if f.MaxDepth > 0 && len(frames) > f.MaxDepth {
+		omitted = len(frames) - f.MaxDepth
+		frames = frames[:f.MaxDepth]
+	}
+
+	style := func(_ framePart, s string) string { return s }
+	color := f.Color.useColor(w)
+	if color {
+		var kinds []string
+		for _, kind := range errorKinds(err) {
+			kinds = append(kinds, o.kind(kind))
+		}
+		style = colorStyle(kinds)
+	}
+	frameString := func(frame Frame) string {
+		return f.frame(frame).render(style)
+	}
+
+	tc, hasTrace := attached[traceContext](err)
+	header := ""
+	if hasTrace {
+		header = style(partTime, tc.String())
+	}
+	if f.OneLine {
+		if hasTrace {
+			fw.write(header, " ")
+		}
+		fw.write("[")
+		for i, frame := range frames {
+			if i > 0 {
+				fw.write(" ")
+			}
+			fw.write("{", frameString(frame), "}")
+		}
+		if omitted > 0 {
+			fw.write(fmt.Sprintf(" {... %d more}", omitted))
+		}
+		fw.write("]")
+		return fw.err
+	}
+
+	separator := ""
+	if hasTrace {
+		fw.write(f.Indent, header)
+		separator = "\n"
+	}
+	for i, frame := range frames {
+		fw.write(separator, f.Indent, frameString(frame))
+		separator = "\n"
+		if multi != nil && i == multiIndex {
+			f.writeTree(fw, multi, color, o)
+		}
+	}
+	if omitted > 0 {
+		fw.write(separator, f.Indent, fmt.Sprintf("... %d more", omitted))
+	}
+	if p := panicError(err); p != nil && len(p.Stack) > 0 && !f.OmitPanicStacks {
+		fw.write("\n", strings.TrimRight(f.Indent, " "))
+		for _, line := range strings.Split(strings.TrimSuffix(string(p.Stack), "\n"), "\n") {
+			fw.write("\n", f.Indent, line)
+		}
+	}
+	return fw.err
+}
+
+// panicError returns the outermost PanicError in the chain of err, not
+// including the errors joined by multi-errors, which are rendered separately.
+func panicError(err error) *PanicError {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if p, ok := err.(*PanicError); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// writeTree writes the annotation stacks of the errors held by m as a tree,
+// below the entry of m.
+func (f Formatter) writeTree(fw *formatWriter, m *MultiError, color bool, o textOptions) {
+	child := f
+	child.Indent = ""
+	child.Color = ColorNever
+	if color {
+		child.Color = ColorAlways
+	}
+	for i, err := range m.errs {
+		branch, indent := "├─ ", "│  "
+		if i == len(m.errs)-1 {
+			branch, indent = "└─ ", "   "
+		}
+		var b strings.Builder
+		_ = child.format(&b, err, o)
+		for j, line := range strings.Split(b.String(), "\n") {
+			prefix := indent
+			if j == 0 {
+				prefix = branch
+			}
+			if line == "" {
+				// Blank lines, such as the one before a panic stack, keep
+				// the tree lines without trailing spaces.
+				fw.write("\n", strings.TrimRight(f.Indent+prefix, " "))
+				continue
+			}
+			fw.write("\n", f.Indent, prefix, line)
+		}
+	}
+}
+
+// stackRoot returns the originating error of the annotation stack of err, or
+// nil if the stack ends in a RemoteError.
+func stackRoot(err error) error {
+	for err != nil {
+		if _, ok := err.(*RemoteError); ok {
+			return nil
+		}
+		w, ok := err.(wrapper)
+		if !ok || w.Underlying() == nil {
+			return err
+		}
+		err = w.Underlying()
+	}
+	return nil
+}
+
+// frame returns frame with the options of f applied.
+func (f Formatter) frame(frame Frame) Frame {
+	if f.OmitLocations {
+		frame.Function, frame.Line = "", 0
+	}
+	if f.OmitCauses {
+		frame.Cause = ""
+	}
+	if f.OmitTimes {
+		frame.Time, frame.Elapsed = time.Time{}, 0
+	}
+	frame.Message = f.truncate(frame.Message)
+	frame.Cause = f.truncate(frame.Cause)
+	return frame
+}
+
+// truncate shortens s to the maximum message length of f.
+func (f Formatter) truncate(s string) string {
+	if f.MaxMessageLength <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= f.MaxMessageLength {
+		return s
+	}
+	if f.MaxMessageLength <= len("...") {
+		return string(runes[:f.MaxMessageLength])
+	}
+	return string(runes[:f.MaxMessageLength-len("...")]) + "..."
+}
+
+// formatWriter writes to w until the first error.
+type formatWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (fw *formatWriter) write(strs ...string) {
+	for _, s := range strs {
+		if fw.err != nil {
+			return
+		}
+		_, fw.err = io.WriteString(fw.w, s)
+	}
+}
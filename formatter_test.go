@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type formatterSuite struct{}
+
+var _ = gc.Suite(&formatterSuite{})
+
+// formatterError returns an error with four entries in its stack, and the
+// locations of each of them.
+func formatterError(c *gc.C) (error, []string) {
+	err := errors.New("first error")
+	loc0 := errorLocationValue(c)
+	err = errors.Annotate(err, "annotation")
+	loc1 := errorLocationValue(c)
+	err = errors.Wrap(err, errors.New("new cause"))
+	loc2 := errorLocationValue(c)
+	err = errors.Annotate(err, "more context")
+	loc3 := errorLocationValue(c)
+	return err, []string{loc0, loc1, loc2, loc3}
+}
+
+func (*formatterSuite) TestPresets(c *gc.C) {
+	err, _ := formatterError(c)
+	c.Assert(errors.Formatter{}.String(err), gc.Equals, errors.ErrorStack(err))
+	details := errors.Formatter{OneLine: true, NewestFirst: true, OmitCauses: true, OmitTimes: true}
+	c.Assert(details.String(err), gc.Equals, errors.Details(err))
+
+	c.Assert(errors.Formatter{}.String(nil), gc.Equals, "")
+	c.Assert(details.String(nil), gc.Equals, "[]")
+}
+
+func (*formatterSuite) TestOptions(c *gc.C) {
+	err, locs := formatterError(c)
+	for i, test := range []struct {
+		formatter errors.Formatter
+		expected  string
+	}{{
+		formatter: errors.Formatter{},
+		expected: locs[0] + ": first error\n" +
+			locs[1] + ": annotation\n" +
+			locs[2] + ": new cause\n" +
+			locs[3] + ": more context",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, NewestFirst: true},
+		expected:  "more context\nnew cause\nannotation\nfirst error",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, OmitCauses: true},
+		expected:  "first error\nannotation\n\nmore context",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, Indent: "    "},
+		expected:  "    first error\n    annotation\n    new cause\n    more context",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, MaxDepth: 2},
+		expected:  "first error\nannotation\n... 2 more",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, MaxDepth: 4},
+		expected:  "first error\nannotation\nnew cause\nmore context",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, MaxMessageLength: 8},
+		expected:  "first...\nannot...\nnew c...\nmore ...",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, OneLine: true},
+		expected:  "[{first error} {annotation} {new cause} {more context}]",
+	}, {
+		formatter: errors.Formatter{OmitLocations: true, OneLine: true, NewestFirst: true, MaxDepth: 1},
+		expected:  "[{more context} {... 3 more}]",
+	}} {
+		c.Logf("test %d: %+v", i, test.formatter)
+		c.Check(test.formatter.String(err), gc.Equals, test.expected)
+	}
+}
+
+func (*formatterSuite) TestFormat(c *gc.C) {
+	err, _ := formatterError(c)
+	var buf bytes.Buffer
+	f := errors.Formatter{OmitLocations: true, Indent: "\t"}
+	c.Assert(f.Format(&buf, err), gc.IsNil)
+	c.Assert(buf.String(), gc.Equals, f.String(err))
+
+	c.Assert(f.Format(failingWriter{}, err), gc.ErrorMatches, "write failed")
+}
+
+func (*formatterSuite) TestTimes(c *gc.C) {
+	previous := errors.SetPolicy(errors.Policy{RecordTime: true})
+	defer errors.SetPolicy(previous)
+	err := errors.Trace(errors.New("boom"))
+
+	c.Assert(errors.ErrorStack(err), gc.Matches, `(?m)^\[.* \+.*\] .*: boom$`)
+	c.Assert(errors.Formatter{OmitTimes: true}.String(err), gc.Not(gc.Matches), `(?s)\[.*`)
+	c.Assert(strings.Contains(errors.Details(err), "+0s"), gc.Equals, false)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("write failed")
+}
@@ -7,7 +7,7 @@ import (
 	stderrors "errors"
 	"fmt"
 	"runtime"
-	"strings"
+	"time"
 )
 
 // New is a drop in replacement for the standard library errors module that records
@@ -29,7 +29,10 @@ func New(message string) error {
 //    return errors.Errorf("validation failed: %s", message)
 //
 func Errorf(format string, args ...interface{}) error {
-	err := &Err{message: fmt.Sprintf(format, args...)}
+	err := &Err{
+		message: fmt.Sprintf(format, args...),
+	}
+	err.format, err.args = retainedFormat(format, args)
 	err.SetLocation(1)
 	return err
 }
@@ -62,7 +65,7 @@ func Trace(other error) error {
 	}
 	err := &Err{previous: other, cause: Cause(other)}
 	err.SetLocation(1)
-	return err
+	return limitDepth(err)
 }
 
 // Annotate is used to add extra context to an existing error. The location of
@@ -84,7 +87,7 @@ func Annotate(other error, message string) error {
 		message:  message,
 	}
 	err.SetLocation(1)
-	return err
+	return limitDepth(err)
 }
 
 // Annotatef is used to add extra context to an existing error. The location of
@@ -105,8 +108,9 @@ func Annotatef(other error, format string, args ...interface{}) error {
 		cause:    Cause(other),
 		message:  fmt.Sprintf(format, args...),
 	}
+	err.format, err.args = retainedFormat(format, args)
 	err.SetLocation(1)
-	return err
+	return limitDepth(err)
 }
 
 // DeferredAnnotatef annotates the given error (when it is not nil) with the given
@@ -127,8 +131,9 @@ func DeferredAnnotatef(err *error, format string, args ...interface{}) {
 		cause:    Cause(*err),
 		previous: *err,
 	}
+	newErr.format, newErr.args = retainedFormat(format, args)
 	newErr.SetLocation(1)
-	*err = newErr
+	*err = limitDepth(newErr)
 }
 
 // Wrap changes the Cause of the error. The location of the Wrap call is also
@@ -163,6 +168,7 @@ func Wrapf(other, newDescriptive error, format string, args ...interface{}) erro
 		previous: other,
 		cause:    newDescriptive,
 	}
+	err.format, err.args = retainedFormat(format, args)
 	err.SetLocation(1)
 	return err
 }
@@ -179,6 +185,7 @@ func Maskf(other error, format string, args ...interface{}) error {
 		message:  fmt.Sprintf(format, args...),
 		previous: other,
 	}
+	err.format, err.args = retainedFormat(format, args)
 	err.SetLocation(1)
 	return err
 }
@@ -227,9 +234,10 @@ type wrapper interface {
 }
 
 var (
-	_ wrapper    = (*Err)(nil)
-	_ Locationer = (*Err)(nil)
-	_ causer     = (*Err)(nil)
+	_ wrapper     = (*Err)(nil)
+	_ Locationer  = (*Err)(nil)
+	_ Timestamper = (*Err)(nil)
+	_ causer      = (*Err)(nil)
 )
 
 // Details returns information about the stack of errors wrapped by err, in
@@ -237,37 +245,17 @@ var (
 //
 // 	[{filename:99: error one} {otherfile:55: cause of error one}]
 //
-// This is a terse alternative to ErrorStack as it returns a single line.
+// This is a terse alternative to ErrorStack as it returns a single line. If a
+// trace context has been attached with WithTraceContext, the line is prefixed
+// with its identifiers:
+//
+// 	trace=4bf92f3577b34da6 span=00f067aa0ba902b7 [{filename:99: error one}]
+//
+// The entries of the stack of a RemoteError are prefixed with "remote: ".
+//
+// Details is a preset of Formatter, which allows the output to be customized.
 func Details(err error) string {
-	if err == nil {
-		return "[]"
-	}
-	var s []byte
-	s = append(s, '[')
-	for {
-		s = append(s, '{')
-		if err, ok := err.(Locationer); ok {
-			file, line := err.Location()
-			if file != "" {
-				s = append(s, fmt.Sprintf("%s:%d", file, line)...)
-				s = append(s, ": "...)
-			}
-		}
-		if cerr, ok := err.(wrapper); ok {
-			s = append(s, cerr.Message()...)
-			err = cerr.Underlying()
-		} else {
-			s = append(s, err.Error()...)
-			err = nil
-		}
-		s = append(s, '}')
-		if err == nil {
-			break
-		}
-		s = append(s, ' ')
-	}
-	s = append(s, ']')
-	return string(s)
+	return limitLength(detailsFormatter.String(err))
 }
 
 // ErrorStack returns a string representation of the annotated error. If the
@@ -284,59 +272,192 @@ func Details(err error) string {
 //     github.com/juju/errors/annotation_test.go:195:
 //     github.com/juju/errors/annotation_test.go:196: more context
 //     github.com/juju/errors/annotation_test.go:197:
+//
+// If recording times is enabled by Policy, each line is prefixed with the
+// time the entry was created and the time elapsed since the previous entry.
+//
+//     [2026-10-18T09:14:02.117Z +0s] github.com/juju/errors/annotation_test.go:193: first error
+//     [2026-10-18T09:14:04.503Z +2.386s] github.com/juju/errors/annotation_test.go:194: annotation
+//
+// If a trace context has been attached with WithTraceContext, the first line
+// is a header holding its identifiers:
+//
+//     trace=4bf92f3577b34da6 span=00f067aa0ba902b7
+//
+// If the error was received from another process as a RemoteError, the lines
+// of the remote stack are prefixed with "remote: ", and followed by the lines
+// of the local annotations:
+//
+//     remote: github.com/juju/juju/apiserver/facade.go:42: unit "mysql/0" not found
+//     remote: github.com/juju/juju/apiserver/facade.go:57: cannot deploy
+//     github.com/juju/juju/api/client.go:108:
+//
+// ErrorStack is the output of the zero Formatter, which allows the output to
+// be customized and written to an io.Writer.
 func ErrorStack(err error) string {
-	return strings.Join(errorStack(err), "\n")
+	return limitLength(defaultFormatter.String(err))
 }
 
 func errorStack(err error) []string {
+	return frameLines(frames(err, textOptions{}))
+}
+
+// frameLines renders each of frames as a line of ErrorStack.
+func frameLines(frames []Frame) []string {
+	var lines []string
+	for _, frame := range frames {
+		lines = append(lines, frame.String())
+	}
+	return lines
+}
+
+// Frame describes a single entry in the annotation stack of an error, as
+// rendered on one line of ErrorStack.
+type Frame struct {
+	// Function is the package path-qualified function name where the entry
+	// was created, or empty if the error did not record a location.
+	Function string
+
+	// Line is the line number the entry was created on inside of Function.
+	Line int
+
+	// Message is the annotation recorded with the entry. For errors that are
+	// not annotated errors, this is the result of their Error method.
+	Message string
+
+	// Cause is the error string of the new cause introduced by this entry,
+	// such as by a call to Wrap, or empty if the cause is unchanged.
+	Cause string
+
+	// Time is when the entry was created, or the zero time if it was not
+	// recorded. See Policy.RecordTime.
+	Time time.Time
+
+	// Elapsed is the time between the previous entry that recorded a time
+	// and this entry. It is zero for the first entry with a time.
+	Elapsed time.Duration
+
+	// Remote reports whether the entry was created in another process, on
+	// the stack of a RemoteError.
+	Remote bool
+}
+
+// frameTimeFormat is the format of the times shown by ErrorStack.
+const frameTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// String returns the frame in the format used by ErrorStack.
+func (f Frame) String() string {
+	return f.render(func(_ framePart, s string) string { return s })
+}
+
+// framePart identifies a part of a frame, so that it can be rendered in its
+// own style.
+type framePart int
+
+const (
+	partTime framePart = iota
+	partRemote
+	partLocation
+	partMessage
+	partCause
+)
+
+// render renders the frame in the format used by ErrorStack, with each part
+// rendered by style.
+func (f Frame) render(style func(part framePart, s string) string) string {
+	var buff []byte
+	if !f.Time.IsZero() {
+		buff = append(buff, style(partTime, fmt.Sprintf("[%s +%s]", f.Time.UTC().Format(frameTimeFormat), f.Elapsed))...)
+		buff = append(buff, ' ')
+	}
+	if f.Remote {
+		buff = append(buff, style(partRemote, "remote:")...)
+		buff = append(buff, ' ')
+	}
+	if f.Function != "" {
+		buff = append(buff, style(partLocation, fmt.Sprintf("%s:%d", f.Function, f.Line))...)
+		buff = append(buff, ": "...)
+	}
+	if f.Message != "" {
+		buff = append(buff, style(partMessage, f.Message)...)
+	}
+	if f.Cause != "" {
+		if f.Message != "" {
+			buff = append(buff, ": "...)
+		}
+		buff = append(buff, style(partCause, f.Cause)...)
+	}
+	return string(buff)
+}
+
+// Frames returns the entries in the annotation stack of err, starting with
+// the originating error and followed by an entry for each annotation or
+// tracing of the error. This is the same information rendered by ErrorStack.
+func Frames(err error) []Frame {
+	return frames(err, textOptions{})
+}
+
+// frames implements Frames, rendering the messages of the error stack with
+// the given text options.
+func frames(err error, o textOptions) []Frame {
 	if err == nil {
 		return nil
 	}
 
 	// We want the first error first
-	var lines []string
+	var frames []Frame
 	for {
-		var buff []byte
-		if err, ok := err.(Locationer); ok {
-			file, line := err.Location()
-			// Strip off the leading GOPATH/src path elements.
-			if file != "" {
-				buff = append(buff, fmt.Sprintf("%s:%d", file, line)...)
-				buff = append(buff, ": "...)
+		if r, ok := err.(*RemoteError); ok {
+			remote := r.frames(o)
+			for i := len(remote) - 1; i >= 0; i-- {
+				frames = append(frames, remote[i])
 			}
+			break
+		}
+		var frame Frame
+		if err, ok := err.(Locationer); ok {
+			frame.Function, frame.Line = err.Location()
+		}
+		if err, ok := err.(Timestamper); ok {
+			frame.Time = err.Timestamp()
 		}
 		if cerr, ok := err.(wrapper); ok {
-			message := cerr.Message()
-			buff = append(buff, message...)
+			frame.Message = cerr.Message()
+			if r, ok := cerr.(messageRenderer); ok {
+				frame.Message = r.renderMessage(o)
+			}
 			// If there is a cause for this error, and it is different to the cause
 			// of the underlying error, then output the error string in the stack trace.
-			var cause error
-			if err1, ok := err.(causer); ok {
-				cause = err1.Cause()
-			}
+			cause := stackCause(err)
 			err = cerr.Underlying()
 			if cause != nil && !sameError(Cause(err), cause) {
-				if message != "" {
-					buff = append(buff, ": "...)
-				}
-				buff = append(buff, cause.Error()...)
+				frame.Cause = errorString(cause, o)
 			}
 		} else {
-			buff = append(buff, err.Error()...)
+			frame.Message = errorString(err, o)
 			err = nil
 		}
-		lines = append(lines, string(buff))
+		frames = append(frames, frame)
 		if err == nil {
 			break
 		}
 	}
-	// reverse the lines to get the original error, which was at the end of
+	// reverse the frames to get the original error, which was at the end of
 	// the list, back to the start.
-	var result []string
-	for i := len(lines); i > 0; i-- {
-		result = append(result, lines[i-1])
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
 	}
-	return result
+	var previous time.Time
+	for i := range frames {
+		if frames[i].Time.IsZero() {
+			continue
+		}
+		if !previous.IsZero() {
+			frames[i].Elapsed = frames[i].Time.Sub(previous)
+		}
+		previous = frames[i].Time
+	}
+	return frames
 }
 
 // Unwrap is a proxy for the Unwrap function in Go's standard `errors` library
@@ -431,6 +552,11 @@ type fmtNoop struct {
 // fmtNoop will not be printed.
 func (*fmtNoop) Format(_ fmt.State, r rune) {}
 
+// renderText implements textRenderer.
+func (f *fmtNoop) renderText(o textOptions) string {
+	return errorString(f.error, o)
+}
+
 // Is implements errors.Is. It useful for us to be able to check if an error
 // chain has fmtNoop for formatting purposes.
 func (f *fmtNoop) Is(err error) bool {
@@ -303,6 +303,31 @@ func (*functionSuite) TestErrorStack(c *gc.C) {
 	}
 }
 
+func (*functionSuite) TestFrames(c *gc.C) {
+	c.Assert(errors.Frames(nil), gc.HasLen, 0)
+
+	err := errors.New("first error")
+	firstLoc := errorLocationValue(c)
+	err = errors.Wrap(err, newError("detailed error"))
+	secondLoc := errorLocationValue(c)
+	err = errors.Annotate(err, "annotated")
+	thirdLoc := errorLocationValue(c)
+
+	frames := errors.Frames(err)
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(frames[0].Message, gc.Equals, "first error")
+	c.Check(frames[1].Message, gc.Equals, "")
+	c.Check(frames[1].Cause, gc.Equals, "detailed error")
+	c.Check(frames[2].Message, gc.Equals, "annotated")
+	c.Check(frames[2].Cause, gc.Equals, "")
+	for i, loc := range []string{firstLoc, secondLoc, thirdLoc} {
+		c.Check(fmt.Sprintf("%s:%d", frames[i].Function, frames[i].Line), gc.Equals, loc)
+	}
+	c.Check(errors.ErrorStack(err), gc.Equals, strings.Join([]string{
+		frames[0].String(), frames[1].String(), frames[2].String(),
+	}, "\n"))
+}
+
 func (*functionSuite) TestFormat(c *gc.C) {
 	formatErrorExpected := &strings.Builder{}
 	err := errors.New("TestFormat")
@@ -1,6 +1,6 @@
 module github.com/juju/errors
 
-go 1.18
+go 1.21
 
 require gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c
 
@@ -0,0 +1,222 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Go calls fn in a new goroutine, and then calls handler in that goroutine
+// with the error returned by fn, unless it is nil. The error is traced with the
+// location of the Go call, where the goroutine was spawned. A panic in fn is
+// recovered as with Recover, and handler is called with a PanicError recording
+// the location of the Go call instead of crashing the program. If fn calls
+// runtime.Goexit, the goroutine exits without calling handler. If handler is
+// nil, errors are ignored.
+//
+// For example:
+//
+//	errors.Go(w.loop, func(err error) {
+//	    logger.Errorf("worker stopped: %+v", err)
+//	})
+func Go(fn func() error, handler func(error)) {
+	function, line := getLocation(1)
+	go func() {
+		if err := runSpawned(fn, function, line, ""); err != nil && handler != nil {
+			handler(err)
+		}
+	}()
+}
+
+// runSpawned calls fn as a goroutine spawned at the given location, returning
+// its error, or the panic it raised, as reported by Go. The error is annotated
+// with label, if it is not empty.
+func runSpawned(fn func() error, function string, line int, label string) error {
+	panicked, err := recoverAt(fn, function, line)
+	if err == nil || panicked && label == "" {
+		// A PanicError already records the location.
+		return err
+	}
+	newErr := &Err{previous: err, cause: Cause(err), message: label}
+	newErr.function, newErr.line = function, line
+	newErr.time = recordTime()
+	return newErr
+}
+
+// GroupMode controls how a Group created by WithContext handles the first
+// error returned by its functions.
+type GroupMode int
+
+const (
+	// CollectAll lets all the functions of the group run to completion, so
+	// that Wait returns all their errors. The context of the group is only
+	// cancelled when Wait returns.
+	CollectAll GroupMode = iota
+
+	// CancelOnError cancels the context of the group when a function first
+	// returns an error, with that error as the cause of the cancellation,
+	// so that the other functions can stop early.
+	CancelOnError
+)
+
+// Group runs functions in goroutines and waits for all of them to return,
+// collecting their errors. Each function is called as by Go, so that its
+// error is traced with the location of the Group.Go call and its panics are
+// recovered. Unlike golang.org/x/sync/errgroup, which only returns the first
+// error, Wait returns a MultiError holding all of them. The zero Group is
+// ready to use, and must not be copied after first use.
+//
+// For example:
+//
+//	g, ctx := errors.WithContext(ctx, errors.CancelOnError)
+//	for _, unit := range units {
+//	    unit := unit
+//	    g.GoLabeled("deploy "+unit, func() error { return deploy(ctx, unit) })
+//	}
+//	if err := g.Wait(); err != nil {
+//	    return errors.Annotate(err, "cannot deploy units")
+//	}
+type Group struct {
+	wg sync.WaitGroup
+
+	mode   GroupMode
+	cancel context.CancelCauseFunc
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// WithContext returns a new Group and a context derived from ctx, which is
+// cancelled when Wait returns, or before as controlled by mode.
+func WithContext(ctx context.Context, mode GroupMode) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{mode: mode, cancel: cancel}, ctx
+}
+
+// Go calls fn in a new goroutine.
+func (g *Group) Go(fn func() error) {
+	function, line := getLocation(1)
+	g.spawn(fn, function, line, "")
+}
+
+// GoLabeled calls fn in a new goroutine, as Go does, and annotates its error
+// with label, which identifies the function in the result of Wait.
+func (g *Group) GoLabeled(label string, fn func() error) {
+	function, line := getLocation(1)
+	g.spawn(fn, function, line, label)
+}
+
+// spawn implements Go and GoLabeled.
+func (g *Group) spawn(fn func() error, function string, line int, label string) {
+	g.mu.Lock()
+	index := len(g.errs)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		err := runSpawned(fn, function, line, label)
+		if err == nil {
+			return
+		}
+		g.mu.Lock()
+		g.errs[index] = err
+		g.mu.Unlock()
+		if g.mode == CancelOnError && g.cancel != nil {
+			// Only the first cancellation has an effect.
+			g.cancel(err)
+		}
+	}()
+}
+
+// Wait waits for all the functions called by Go and GoLabeled to return, and
+// returns a MultiError holding their non-nil errors in the order of the calls,
+// or nil if there were none. It records its own location in the MultiError.
+func (g *Group) Wait() error {
+	function, line := getLocation(1)
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var errs []error
+	for _, err := range g.errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	var result error
+	if len(errs) > 0 {
+		result = &MultiError{errs: errs, function: function, line: line}
+	}
+	if g.cancel != nil {
+		g.cancel(result)
+	}
+	return result
+}
+
+// MultiError is the error returned by Group.Wait, which holds the errors of
+// several functions. It satisfies errors.Is and errors.As for each of them,
+// so it matches each of their kinds. Its error string lists their error
+// strings, and ErrorStack renders their annotation stacks as a tree below its
+// own entry.
+type MultiError struct {
+	errs     []error
+	function string
+	line     int
+}
+
+// Errors returns the errors held by m.
+func (m *MultiError) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// Unwrap returns the errors held by m, for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Error implements error. The error string of a single error is unchanged.
+func (m *MultiError) Error() string {
+	return limitLength(m.renderText(textOptions{}))
+}
+
+// renderText implements textRenderer.
+func (m *MultiError) renderText(o textOptions) string {
+	if len(m.errs) == 1 {
+		return errorString(m.errs[0], o)
+	}
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = errorString(err, o)
+	}
+	return m.Message() + ": " + strings.Join(messages, "; ")
+}
+
+// Message implements wrapper, summarizing the errors of m in its entry in
+// the annotation stack.
+func (m *MultiError) Message() string {
+	if len(m.errs) == 1 {
+		return "1 error"
+	}
+	return fmt.Sprintf("%d errors", len(m.errs))
+}
+
+// Underlying implements wrapper. The errors of m are not part of its
+// annotation stack.
+func (m *MultiError) Underlying() error {
+	return nil
+}
+
+// Location implements Locationer, returning the location of the Wait call.
+func (m *MultiError) Location() (string, int) {
+	return m.function, m.line
+}
+
+// Format implements fmt.Formatter.
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, m)
+}
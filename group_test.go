@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type groupSuite struct{}
+
+var _ = gc.Suite(&groupSuite{})
+
+func (*groupSuite) TestGo(c *gc.C) {
+	errs := make(chan error, 1)
+	errors.Go(func() error { return errors.NotFoundf("unit") }, func(err error) { errs <- err })
+	loc := errorLocationValue(c)
+
+	err := <-errs
+	c.Assert(err, gc.ErrorMatches, "unit not found")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.ErrorStack(err), gc.Matches, `(?s).*: unit not found\n`+regexp.QuoteMeta(loc)+`: `)
+}
+
+func (*groupSuite) TestGoPanic(c *gc.C) {
+	errs := make(chan error, 1)
+	errors.Go(func() error { panic("boom") }, func(err error) { errs <- err })
+	loc := errorLocationValue(c)
+
+	err := <-errs
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+	c.Assert(errors.Formatter{OmitPanicStacks: true}.String(err), gc.Equals, loc+": panic: boom")
+}
+
+func (*groupSuite) TestGroup(c *gc.C) {
+	var g errors.Group
+	release := make(chan struct{})
+	notFound := func() error {
+		<-release
+		return errors.NotFoundf("unit")
+	}
+	boom := func() error {
+		defer close(release)
+		panic("boom")
+	}
+	g.Go(notFound)
+	loc0 := errorLocationValue(c)
+	g.Go(func() error { return nil })
+	g.Go(boom)
+	loc2 := errorLocationValue(c)
+
+	// The errors are in the order of the Go calls.
+	err := g.Wait()
+	c.Assert(err, gc.ErrorMatches, "2 errors: unit not found; panic: boom")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+
+	errs := err.(*errors.MultiError).Errors()
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errors.ErrorStack(errs[0]), gc.Matches, `(?s).*: unit not found\n`+regexp.QuoteMeta(loc0)+`: `)
+	c.Assert(errors.Formatter{OmitPanicStacks: true}.String(errs[1]), gc.Equals, loc2+": panic: boom")
+}
+
+func (*groupSuite) TestGroupGoexit(c *gc.C) {
+	var g errors.Group
+	g.Go(func() error {
+		runtime.Goexit()
+		return nil
+	})
+	g.Go(func() error { return errors.New("boom") })
+	c.Assert(g.Wait(), gc.ErrorMatches, "boom")
+}
+
+func (*groupSuite) TestGroupNoErrors(c *gc.C) {
+	var g errors.Group
+	c.Assert(g.Wait(), gc.IsNil)
+	g.Go(func() error { return nil })
+	c.Assert(g.Wait(), gc.IsNil)
+}
+
+func (*groupSuite) TestGroupErrorStack(c *gc.C) {
+	var g errors.Group
+	release := make(chan struct{})
+	notFound := func() error {
+		<-release
+		return errors.NotFoundf("unit")
+	}
+	boom := func() error {
+		defer close(release)
+		panic("boom")
+	}
+	g.GoLabeled("deploy mysql/0", notFound)
+	loc0 := errorLocationValue(c)
+	g.Go(boom)
+	loc1 := errorLocationValue(c)
+	err := g.Wait()
+	loc2 := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	loc3 := errorLocationValue(c)
+
+	c.Assert(err, gc.ErrorMatches, "cannot deploy: 2 errors: deploy mysql/0: unit not found; panic: boom")
+	c.Assert(errors.ErrorStack(err), gc.Matches, "(?s)"+
+		regexp.QuoteMeta(loc2)+": 2 errors\n"+
+		"├─ .*: unit not found\n"+
+		"│  "+regexp.QuoteMeta(loc0)+": deploy mysql/0\n"+
+		"└─ "+regexp.QuoteMeta(loc1)+": panic: boom\n"+
+		"\n"+
+		"   goroutine .*\n"+
+		regexp.QuoteMeta(loc3)+": cannot deploy")
+	c.Assert(errors.Details(err), gc.Equals, "[{"+loc3+": cannot deploy} {"+loc2+": 2 errors}]")
+
+	f := errors.Formatter{NewestFirst: true, Indent: "  ", OmitPanicStacks: true}
+	c.Assert(f.String(err), gc.Matches, ""+
+		"  "+regexp.QuoteMeta(loc3)+": cannot deploy\n"+
+		"  "+regexp.QuoteMeta(loc2)+": 2 errors\n"+
+		"  ├─ "+regexp.QuoteMeta(loc0)+": deploy mysql/0\n"+
+		"  │  .*: unit not found\n"+
+		"  └─ "+regexp.QuoteMeta(loc1)+": panic: boom")
+}
+
+func (*groupSuite) TestGroupPanicLabeled(c *gc.C) {
+	var g errors.Group
+	g.GoLabeled("worker", func() error { panic("boom") })
+	err := g.Wait()
+	c.Assert(err, gc.ErrorMatches, "worker: panic: boom")
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+}
+
+func (*groupSuite) TestCancelOnError(c *gc.C) {
+	g, ctx := errors.WithContext(context.Background(), errors.CancelOnError)
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func() error { return errors.NotFoundf("unit") })
+
+	err := g.Wait()
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Is(err, context.Canceled), gc.Equals, true)
+	c.Assert(errors.Is(context.Cause(ctx), errors.NotFound), gc.Equals, true)
+}
+
+func (*groupSuite) TestCollectAll(c *gc.C) {
+	g, ctx := errors.WithContext(context.Background(), errors.CollectAll)
+	failed := make(chan struct{})
+	g.Go(func() error {
+		defer close(failed)
+		return errors.NotFoundf("unit")
+	})
+	g.Go(func() error {
+		<-failed
+		// The failure of the other function does not cancel the context.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errors.BadRequestf("request")
+	})
+
+	err := g.Wait()
+	c.Assert(err, gc.ErrorMatches, "2 errors: unit not found; request")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Is(err, errors.BadRequest), gc.Equals, true)
+	c.Assert(ctx.Err(), gc.Equals, context.Canceled)
+}
+
+func (*groupSuite) TestMultiErrorSingle(c *gc.C) {
+	var g errors.Group
+	g.Go(func() error { return errors.New("boom") })
+	err := g.Wait()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(errors.StatusCode(err), gc.Equals, int32(2))
+	c.Assert(err.(*errors.MultiError).Errors(), gc.HasLen, 1)
+}
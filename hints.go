@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"sync"
+)
+
+// Hint describes a next step for the user to take to resolve an error.
+type Hint struct {
+	// Text is a short remediation, such as "run juju login".
+	Text string
+
+	// URL is a link to documentation about the error.
+	URL string
+}
+
+// hint is the attachment recorded by WithHint and WithHelpURL.
+type hint Hint
+
+var (
+	kindHintsMutex sync.RWMutex
+	kindHints      = make(map[ConstError][]Hint)
+)
+
+// RegisterHint registers h as a default hint for errors of the given kind.
+// The default hints of every kind an error satisfies are returned by Hints
+// after those attached to the error.
+//
+// For example:
+//
+//	errors.RegisterHint(errors.Unauthorized, errors.Hint{Text: "run juju login"})
+func RegisterHint(kind ConstError, h Hint) {
+	kindHintsMutex.Lock()
+	defer kindHintsMutex.Unlock()
+	kindHints[kind] = append(kindHints[kind], h)
+}
+
+// WithHint attaches a remediation hint with the given text to err and records
+// the location of the WithHint call, much like Trace. The message, Cause and
+// kinds of err are unchanged. If err is nil, the result will be nil.
+//
+// For example:
+//
+//	return errors.WithHint(err, "wait for the machine to start")
+func WithHint(err error, text string) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: hint{Text: text},
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// WithHelpURL attaches a link to documentation about err and records the
+// location of the WithHelpURL call, much like Trace. The message, Cause and
+// kinds of err are unchanged. If err is nil, the result will be nil.
+func WithHelpURL(err error, url string) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: hint{URL: url},
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// Hints returns the hints for err so that they can be shown to the user along
+// with the error. These are the hints attached by WithHint and WithHelpURL,
+// from the outermost to the innermost, followed by the default hints
+// registered for each kind of error that err satisfies. Duplicate hints are
+// only returned once.
+func Hints(err error) []Hint {
+	var hints []Hint
+	add := func(h Hint) {
+		for _, existing := range hints {
+			if existing == h {
+				return
+			}
+		}
+		hints = append(hints, h)
+	}
+	for _, h := range allAttached[hint](err) {
+		add(Hint(h))
+	}
+
+	kindHintsMutex.RLock()
+	defer kindHintsMutex.RUnlock()
+	for _, kind := range errorKinds(err) {
+		for _, h := range kindHints[kind] {
+			add(h)
+		}
+	}
+	return hints
+}
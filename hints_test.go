@@ -0,0 +1,55 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type hintsSuite struct{}
+
+var _ = gc.Suite(&hintsSuite{})
+
+func (*hintsSuite) TestWithHintNil(c *gc.C) {
+	c.Assert(errors.WithHint(nil, "try again"), gc.IsNil)
+	c.Assert(errors.WithHelpURL(nil, "https://juju.is/docs"), gc.IsNil)
+	c.Assert(errors.Hints(nil), gc.HasLen, 0)
+}
+
+func (*hintsSuite) TestHints(c *gc.C) {
+	first := errors.NotProvisionedf("machine 0")
+	err := errors.WithHint(first, "wait for the machine to start")
+	loc := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	err = errors.WithHelpURL(err, "https://juju.is/docs/machines")
+	err = errors.WithHint(err, "run juju status")
+
+	c.Assert(err.Error(), gc.Equals, "cannot deploy: machine 0 not provisioned")
+	c.Assert(errors.Is(err, errors.NotProvisioned), gc.Equals, true)
+	c.Assert(errors.ErrorStack(err), Contains, loc)
+	c.Assert(errors.Hints(err), gc.DeepEquals, []errors.Hint{
+		{Text: "run juju status"},
+		{URL: "https://juju.is/docs/machines"},
+		{Text: "wait for the machine to start"},
+	})
+}
+
+func (*hintsSuite) TestRegisteredHints(c *gc.C) {
+	kind := errors.ConstError("hints test kind")
+	errors.RegisterHint(kind, errors.Hint{Text: "run juju login", URL: "https://juju.is/docs/login"})
+	defer errors.UnregisterHints(kind)
+
+	err := errors.WithType(errors.New("token expired"), kind)
+	err = errors.WithHint(err, "check your credentials")
+	err = errors.WithHint(err, "run juju login")
+
+	c.Assert(errors.Hints(err), gc.DeepEquals, []errors.Hint{
+		{Text: "run juju login"},
+		{Text: "check your credentials"},
+		{Text: "run juju login", URL: "https://juju.is/docs/login"},
+	})
+	c.Assert(errors.Hints(errors.New("other")), gc.HasLen, 0)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxResponseBody is the maximum size of a response body read by
+// FromResponse.
+const maxResponseBody = 1 << 20
+
+// httpStatusKinds maps HTTP status codes to the error types of the errors
+// returned by FromResponse.
+var httpStatusKinds = map[int]ConstError{
+	http.StatusBadRequest:          BadRequest,
+	http.StatusUnauthorized:        Unauthorized,
+	http.StatusForbidden:           Forbidden,
+	http.StatusNotFound:            NotFound,
+	http.StatusMethodNotAllowed:    MethodNotAllowed,
+	http.StatusRequestTimeout:      Timeout,
+	http.StatusConflict:            AlreadyExists,
+	http.StatusUnprocessableEntity: NotValid,
+	http.StatusTooManyRequests:     QuotaLimitExceeded,
+	http.StatusNotImplemented:      NotImplemented,
+	http.StatusServiceUnavailable:  NotYetAvailable,
+	http.StatusGatewayTimeout:      Timeout,
+}
+
+// FromResponse returns an error describing the error response resp, or nil if
+// resp is not an error response, that is, if its status code is less than 400.
+//
+// The error satisfies the error type corresponding to the status code, such as
+// NotFound for 404 (Not Found), Unauthorized for 401, Forbidden for 403,
+// AlreadyExists for 409 (Conflict) and QuotaLimitExceeded for 429 (Too Many
+// Requests), and the Locationer interface, with the location of the call to
+// FromResponse. It wraps a RemoteError holding the error sent by the server:
+//
+//   - if the body has the "application/json" content type and was encoded
+//     by EncodeJSON, the decoded error, which may satisfy other error types;
+//   - if the body has the "application/x-protobuf" content type and was
+//     encoded by EncodeStatus, the decoded error;
+//   - otherwise, an error whose message is the body as text, or the status
+//     text if the body is empty.
+//
+// FromResponse reads up to 1MiB of the body of resp, but does not close it.
+//
+// For example:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//	    return errors.Trace(err)
+//	}
+//	defer resp.Body.Close()
+//	if err := errors.FromResponse(resp); err != nil {
+//	    return err
+//	}
+func FromResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < 400 {
+		return nil
+	}
+	remote := responseError(resp)
+	var err error = newLocationError(remote, 1)
+	if kind, ok := httpStatusKinds[resp.StatusCode]; ok && !Is(remote, kind) {
+		err = &errWithType{error: err, errType: kind}
+	}
+	return err
+}
+
+// responseError returns the error sent by the server in the body of resp.
+func responseError(resp *http.Response) error {
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	var (
+		decoded error
+		err     error
+	)
+	switch mediaType {
+	case "application/json":
+		decoded, err = DecodeJSON(body)
+	case "application/x-protobuf", "application/protobuf":
+		decoded, err = DecodeStatus(body)
+	}
+	if err == nil && decoded != nil {
+		return decoded
+	}
+
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	if message == "" {
+		message = resp.Status
+	}
+	var origin Origin
+	if resp.Request != nil && resp.Request.URL != nil {
+		origin.Host = resp.Request.URL.Host
+	}
+	return NewRemoteError(&messageError{message: message}, origin)
+}
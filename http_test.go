@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type httpSuite struct{}
+
+var _ = gc.Suite(&httpSuite{})
+
+// response returns the response recorded for a handler writing the given
+// status code, content type and body.
+func response(code int, contentType string, body []byte) *http.Response {
+	recorder := httptest.NewRecorder()
+	if contentType != "" {
+		recorder.Header().Set("Content-Type", contentType)
+	}
+	recorder.WriteHeader(code)
+	recorder.Write(body)
+	resp := recorder.Result()
+	resp.Request = httptest.NewRequest("GET", "http://controller.example.com/units", nil)
+	return resp
+}
+
+func (*httpSuite) TestSuccess(c *gc.C) {
+	c.Assert(errors.FromResponse(nil), gc.IsNil)
+	c.Assert(errors.FromResponse(response(http.StatusOK, "", nil)), gc.IsNil)
+	c.Assert(errors.FromResponse(response(http.StatusFound, "", nil)), gc.IsNil)
+}
+
+func (*httpSuite) TestStatusKinds(c *gc.C) {
+	for code, kind := range map[int]errors.ConstError{
+		http.StatusBadRequest:      errors.BadRequest,
+		http.StatusUnauthorized:    errors.Unauthorized,
+		http.StatusForbidden:       errors.Forbidden,
+		http.StatusNotFound:        errors.NotFound,
+		http.StatusConflict:        errors.AlreadyExists,
+		http.StatusTooManyRequests: errors.QuotaLimitExceeded,
+		http.StatusGatewayTimeout:  errors.Timeout,
+	} {
+		err := errors.FromResponse(response(code, "text/plain", []byte("go away\n")))
+		c.Check(err, gc.ErrorMatches, "go away")
+		c.Check(errors.Is(err, kind), gc.Equals, true, gc.Commentf("status %d", code))
+	}
+}
+
+func (*httpSuite) TestTextBody(c *gc.C) {
+	err := errors.FromResponse(response(http.StatusNotFound, "text/plain", []byte(`unit "mysql/0" not found`)))
+	loc := errorLocationValue(c)
+
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+	var locationer errors.Locationer
+	c.Assert(stderrors.As(err, &locationer), gc.Equals, true)
+	function, line := locationer.Location()
+	c.Assert(fmt.Sprintf("%s:%d", function, line), gc.Equals, loc)
+	c.Assert(errors.ErrorStack(err), gc.Equals, `remote: unit "mysql/0" not found`+"\n"+loc+": ")
+	remote, ok := errors.AsType[*errors.RemoteError](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(remote.Origin.Host, gc.Equals, "controller.example.com")
+
+	err = errors.FromResponse(response(http.StatusInternalServerError, "", nil))
+	c.Assert(err, gc.ErrorMatches, "Internal Server Error")
+	for _, errInfo := range allErrors {
+		c.Check(errors.Is(err, errInfo.errType), gc.Equals, false)
+	}
+}
+
+func (*httpSuite) TestJSONBody(c *gc.C) {
+	sent := errors.Annotate(errors.UserNotFoundf("bob"), "cannot log in")
+	body, err := errors.EncodeJSON(sent)
+	c.Assert(err, gc.IsNil)
+
+	err = errors.FromResponse(response(http.StatusNotFound, "application/json; charset=utf-8", body))
+	c.Assert(err.Error(), gc.Equals, sent.Error())
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Is(err, errors.UserNotFound), gc.Equals, true)
+	c.Assert(errors.ErrorStack(err), gc.Matches, `(?s)remote: .*: cannot log in\n.*TestJSONBody:\d+: `)
+
+	// Other JSON bodies are treated as text.
+	err = errors.FromResponse(response(http.StatusConflict, "application/json", []byte(`{"error": "exists"}`)))
+	c.Assert(err, gc.ErrorMatches, `\{"error": "exists"\}`)
+	c.Assert(errors.Is(err, errors.AlreadyExists), gc.Equals, true)
+}
+
+func (*httpSuite) TestStatusBody(c *gc.C) {
+	body, err := errors.EncodeStatus(errors.Forbiddenf("deleting the controller"))
+	c.Assert(err, gc.IsNil)
+
+	err = errors.FromResponse(response(http.StatusForbidden, "application/x-protobuf", body))
+	c.Assert(err, gc.ErrorMatches, "deleting the controller")
+	c.Assert(errors.Is(err, errors.Forbidden), gc.Equals, true)
+	_, ok := errors.AsType[*errors.RemoteError](err)
+	c.Assert(ok, gc.Equals, true)
+}
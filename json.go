@@ -0,0 +1,492 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+)
+
+// JSONVersion is the version of the schema written by EncodeJSON.
+const JSONVersion = 1
+
+// jsonDocument is the top level of the JSON encoding of an error.
+type jsonDocument struct {
+	Version int         `json:"version"`
+	Origin  *jsonOrigin `json:"origin,omitempty"`
+	Error   *jsonError  `json:"error,omitempty"`
+}
+
+// Node types of the JSON encoding.
+const (
+	jsonTypeErr      = "err"
+	jsonTypeLocation = "location"
+	jsonTypeKind     = "kind"
+	jsonTypeConst    = "const"
+	jsonTypeHidden   = "hidden"
+	jsonTypeMessage  = "message"
+	jsonTypeOpaque   = "opaque"
+	jsonTypeCustom   = "custom"
+	jsonTypeRemote   = "remote"
+)
+
+// jsonError is a single error in the JSON encoding of an error chain.
+type jsonError struct {
+	Type     string      `json:"type"`
+	Name     string      `json:"name,omitempty"`
+	Data     []byte      `json:"data,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Kind     string      `json:"kind,omitempty"`
+	Origin   *jsonOrigin `json:"origin,omitempty"`
+	Function string      `json:"function,omitempty"`
+	Line     int         `json:"line,omitempty"`
+	Time     *time.Time  `json:"time,omitempty"`
+
+	Base           *jsonError   `json:"base,omitempty"`
+	Wrapped        *jsonError   `json:"wrapped,omitempty"`
+	Errors         []*jsonError `json:"errors,omitempty"`
+	Cause          *jsonError   `json:"cause,omitempty"`
+	CauseInherited bool         `json:"causeInherited,omitempty"`
+
+	Info        *jsonErrorInfo `json:"info,omitempty"`
+	Fields      []jsonField    `json:"fields,omitempty"`
+	UserMessage *string        `json:"userMessage,omitempty"`
+	Hint        *jsonHint      `json:"hint,omitempty"`
+	Trace       *jsonTrace     `json:"trace,omitempty"`
+	RetryDelay  *time.Duration `json:"retryDelay,omitempty"`
+}
+
+type jsonOrigin struct {
+	Service string `json:"service,omitempty"`
+	Host    string `json:"host,omitempty"`
+}
+
+type jsonErrorInfo struct {
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type jsonField struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type jsonHint struct {
+	Text string `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonTrace struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId,omitempty"`
+}
+
+// EncodeJSON encodes err, including its full chain, as JSON so that it can be
+// sent across a process boundary and decoded with DecodeJSON. The decoded
+// error is held in a RemoteError: it has the same Error output as err and
+// satisfies Is for the same kinds, but its ErrorStack and Details show the
+// entries of err marked as remote, each prefixed with "remote: ".
+//
+// The encoding is a document holding the schema version, the origin set with
+// SetLocalOrigin, which is omitted if none was set, and the outermost error:
+//
+//	{"version": 1, "origin": {"service": ..., "host": ...}, "error": {...}}
+//
+// Each error in the chain is an object whose "type" is one of:
+//
+//	err       an annotated error, such as those created by New, Trace and
+//	          Annotate, with its "message", the "wrapped" previous error, and
+//	          either "causeInherited" if its cause is the cause of the previous
+//	          error or a "cause" introduced by Wrap.
+//	location  an error with a location, such as those created by NotFoundf
+//	          and SetLocation, with the "wrapped" error.
+//	kind      an error made to satisfy an error type, such as by WithType
+//	          or NewNotFound, with the "kind" and the "wrapped" error.
+//	const     a ConstError, with its text as the "message".
+//	hidden    an error hidden from formatting with Hide, with the "wrapped"
+//	          error.
+//	message   an error annotated with a "message" by a constructor such as
+//	          NewNotFound, with the "wrapped" error if any.
+//	custom    an error of a type registered with RegisterErrorType, with
+//	          the registered "name", its error string as the "message", the
+//	          base64 encoded "data" returned by the registered encode
+//	          function, and either the "base" err of the Err embedded in it
+//	          or the "wrapped" error.
+//	remote    a RemoteError, with its "origin" and the "wrapped" remote
+//	          error.
+//	opaque    any other error, with its error string as the "message", and
+//	          the "wrapped" error or the "errors" joined by it, if any.
+//
+// Errors may also have a "function", "line" and "time" where they were
+// created, and the err type may hold an "info", "fields", "userMessage",
+// "hint", "trace" or "retryDelay", in nanoseconds, attached to it. Values
+// attached with WithValue are not encoded, and values marked with Secret
+// remain redacted.
+func EncodeJSON(err error) ([]byte, error) {
+	j, encodeErr := encodeJSONError(err)
+	if encodeErr != nil {
+		return nil, Trace(encodeErr)
+	}
+	return json.Marshal(jsonDocument{
+		Version: JSONVersion,
+		Origin:  encodeJSONOrigin(LocalOrigin()),
+		Error:   j,
+	})
+}
+
+// DecodeJSON decodes an error encoded by EncodeJSON. The result is a
+// RemoteError holding the decoded error and the origin of the encoding, or nil
+// if the encoded error was nil.
+func DecodeJSON(data []byte) (error, error) {
+	var doc jsonDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, NewNotValid(err, "decoding error")
+	}
+	if doc.Version != JSONVersion {
+		return nil, NotSupportedf("error encoding version %d", doc.Version)
+	}
+	decoded, err := decodeJSONError(doc.Error)
+	if err != nil {
+		return nil, Trace(err)
+	}
+	return NewRemoteError(decoded, decodeJSONOrigin(doc.Origin)), nil
+}
+
+func encodeJSONError(err error) (*jsonError, error) {
+	if err == nil {
+		return nil, nil
+	}
+	if rt, ok := registeredTypeOf(err); ok {
+		return encodeJSONCustom(err, rt.name, rt.encode)
+	}
+
+	var (
+		j         *jsonError
+		wrapped   error
+		encodeErr error
+	)
+	switch e := err.(type) {
+	case *Err:
+		j = &jsonError{
+			Type:     jsonTypeErr,
+			Message:  e.message,
+			Function: e.function,
+			Line:     e.line,
+			Time:     jsonTime(e.time),
+		}
+		wrapped = e.previous
+		if e.cause != nil {
+			if sameError(Cause(e.previous), e.cause) {
+				j.CauseInherited = true
+			} else if j.Cause, encodeErr = encodeJSONError(e.cause); encodeErr != nil {
+				return nil, encodeErr
+			}
+		}
+		encodeJSONAttachment(j, e.attachment)
+	case *locationError:
+		j = &jsonError{
+			Type:     jsonTypeLocation,
+			Function: e.function,
+			Line:     e.line,
+			Time:     jsonTime(e.time),
+		}
+		wrapped = e.error
+	case *errWithType:
+		j = &jsonError{Type: jsonTypeKind, Kind: string(e.errType)}
+		wrapped = e.error
+	case *PanicError:
+		// A PanicError is encoded as the Panic kind over its location, over
+		// its message, which wraps the panic value if it is an error.
+		valueErr, _ := e.Value.(error)
+		message := &opaqueError{message: e.message, wrapped: valueErr}
+		j = &jsonError{Type: jsonTypeKind, Kind: string(Panic)}
+		wrapped = &locationError{error: message, function: e.function, line: e.line, time: e.time}
+	case ConstError:
+		j = &jsonError{Type: jsonTypeConst, Message: string(e)}
+	case *fmtNoop:
+		j = &jsonError{Type: jsonTypeHidden}
+		wrapped = e.error
+	case *messageError:
+		j = &jsonError{Type: jsonTypeMessage, Message: e.message}
+		wrapped = e.err
+	case *unredactedError:
+		// Never encode secrets.
+		return encodeJSONError(e.err)
+	case *RemoteError:
+		j = &jsonError{Type: jsonTypeRemote, Origin: encodeJSONOrigin(e.Origin)}
+		wrapped = e.err
+	case *UnregisteredError:
+		return encodeJSONCustom(e, e.TypeName, func(error) ([]byte, error) {
+			return e.Data, nil
+		})
+	case interface{ Unwrap() []error }:
+		j = &jsonError{Type: jsonTypeOpaque, Message: err.Error()}
+		for _, err := range e.Unwrap() {
+			je, encodeErr := encodeJSONError(err)
+			if encodeErr != nil {
+				return nil, encodeErr
+			}
+			j.Errors = append(j.Errors, je)
+		}
+	default:
+		j = &jsonError{Type: jsonTypeOpaque, Message: err.Error()}
+		wrapped = stderrors.Unwrap(err)
+	}
+	if j.Wrapped, encodeErr = encodeJSONError(wrapped); encodeErr != nil {
+		return nil, encodeErr
+	}
+	return j, nil
+}
+
+// encodeJSONCustom encodes err, which is of a type registered with
+// RegisterErrorType under name, using encode for its type-specific data.
+func encodeJSONCustom(err error, name string, encode func(error) ([]byte, error)) (*jsonError, error) {
+	data, encodeErr := encode(err)
+	if encodeErr != nil {
+		return nil, Annotatef(encodeErr, "encoding error type %q", name)
+	}
+	j := &jsonError{
+		Type:    jsonTypeCustom,
+		Name:    name,
+		Data:    data,
+		Message: err.Error(),
+	}
+	switch e := err.(type) {
+	case *UnregisteredError:
+		if e.base != nil {
+			j.Base, encodeErr = encodeJSONError(e.base)
+		} else {
+			j.Wrapped, encodeErr = encodeJSONError(e.wrapped)
+		}
+	case errorCore:
+		j.Base, encodeErr = encodeJSONError(e.core())
+	default:
+		j.Wrapped, encodeErr = encodeJSONError(stderrors.Unwrap(err))
+	}
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	return j, nil
+}
+
+func encodeJSONAttachment(j *jsonError, attachment interface{}) {
+	switch a := attachment.(type) {
+	case ErrorInfo:
+		j.Info = &jsonErrorInfo{Reason: a.Reason, Domain: a.Domain, Metadata: a.Metadata}
+	case fieldSet:
+		for _, f := range a {
+			value, err := json.Marshal(f.Value)
+			if err != nil {
+				value, _ = json.Marshal(fmt.Sprint(f.Value))
+			}
+			j.Fields = append(j.Fields, jsonField{Key: f.Key, Value: value})
+		}
+	case userMessage:
+		msg := string(a)
+		j.UserMessage = &msg
+	case hint:
+		j.Hint = &jsonHint{Text: a.Text, URL: a.URL}
+	case traceContext:
+		j.Trace = &jsonTrace{TraceID: a.traceID, SpanID: a.spanID}
+	case retryDelay:
+		delay := time.Duration(a)
+		j.RetryDelay = &delay
+	}
+}
+
+func encodeJSONOrigin(origin Origin) *jsonOrigin {
+	if origin == (Origin{}) {
+		return nil
+	}
+	return &jsonOrigin{Service: origin.Service, Host: origin.Host}
+}
+
+func decodeJSONOrigin(j *jsonOrigin) Origin {
+	if j == nil {
+		return Origin{}
+	}
+	return Origin{Service: j.Service, Host: j.Host}
+}
+
+func jsonTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func decodeJSONError(j *jsonError) (error, error) {
+	if j == nil {
+		return nil, nil
+	}
+	wrapped, err := decodeJSONError(j.Wrapped)
+	if err != nil {
+		return nil, err
+	}
+	var t time.Time
+	if j.Time != nil {
+		t = *j.Time
+	}
+
+	switch j.Type {
+	case jsonTypeErr:
+		e := &Err{
+			message:  j.Message,
+			previous: wrapped,
+			function: j.Function,
+			line:     j.Line,
+			time:     t,
+		}
+		if j.CauseInherited {
+			e.cause = Cause(wrapped)
+		} else if e.cause, err = decodeJSONError(j.Cause); err != nil {
+			return nil, err
+		}
+		if e.attachment, err = decodeJSONAttachment(j); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case jsonTypeLocation:
+		return &locationError{error: wrapped, function: j.Function, line: j.Line, time: t}, nil
+	case jsonTypeKind:
+		if wrapped == nil {
+			return nil, NotValidf("kind error without wrapped error")
+		}
+		return &errWithType{error: wrapped, errType: ConstError(j.Kind)}, nil
+	case jsonTypeConst:
+		return ConstError(j.Message), nil
+	case jsonTypeHidden:
+		if wrapped == nil {
+			return nil, NotValidf("hidden error without wrapped error")
+		}
+		return &fmtNoop{wrapped}, nil
+	case jsonTypeMessage:
+		return &messageError{message: j.Message, err: wrapped}, nil
+	case jsonTypeCustom:
+		return decodeJSONCustom(j, wrapped)
+	case jsonTypeRemote:
+		if wrapped == nil {
+			return nil, NotValidf("remote error without wrapped error")
+		}
+		return &RemoteError{Origin: decodeJSONOrigin(j.Origin), err: wrapped}, nil
+	case jsonTypeOpaque:
+		o := &opaqueError{message: j.Message, wrapped: wrapped}
+		for _, je := range j.Errors {
+			err, decodeErr := decodeJSONError(je)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			o.errors = append(o.errors, err)
+		}
+		if o.errors != nil {
+			return &multiOpaqueError{o}, nil
+		}
+		return o, nil
+	}
+	return nil, NotValidf("error type %q", j.Type)
+}
+
+// decodeJSONCustom decodes an error of a type registered with
+// RegisterErrorType, returning an *UnregisteredError if the type is not
+// registered.
+func decodeJSONCustom(j *jsonError, wrapped error) (error, error) {
+	base, err := decodeJSONError(j.Base)
+	if err != nil {
+		return nil, err
+	}
+	baseErr, ok := base.(*Err)
+	if base != nil && !ok {
+		return nil, NotValidf("base of error type %q", j.Name)
+	}
+	rt, ok := registeredTypeNamed(j.Name)
+	if !ok {
+		return &UnregisteredError{
+			TypeName: j.Name,
+			Data:     j.Data,
+			message:  j.Message,
+			base:     baseErr,
+			wrapped:  wrapped,
+		}, nil
+	}
+	parts := DecodedParts{Wrapped: wrapped}
+	if baseErr != nil {
+		parts.Err = *baseErr
+	}
+	decoded, err := rt.decode(j.Data, parts)
+	if err != nil {
+		return nil, Annotatef(err, "decoding error type %q", j.Name)
+	}
+	return decoded, nil
+}
+
+func decodeJSONAttachment(j *jsonError) (interface{}, error) {
+	switch {
+	case j.Info != nil:
+		return ErrorInfo{Reason: j.Info.Reason, Domain: j.Info.Domain, Metadata: j.Info.Metadata}, nil
+	case j.Fields != nil:
+		fields := make(fieldSet, len(j.Fields))
+		for i, f := range j.Fields {
+			fields[i].Key = f.Key
+			if err := json.Unmarshal(f.Value, &fields[i].Value); err != nil {
+				return nil, NewNotValid(err, fmt.Sprintf("field %q", f.Key))
+			}
+		}
+		return fields, nil
+	case j.UserMessage != nil:
+		return userMessage(*j.UserMessage), nil
+	case j.Hint != nil:
+		return hint{Text: j.Hint.Text, URL: j.Hint.URL}, nil
+	case j.Trace != nil:
+		return traceContext{traceID: j.Trace.TraceID, spanID: j.Trace.SpanID}, nil
+	case j.RetryDelay != nil:
+		return retryDelay(*j.RetryDelay), nil
+	}
+	return nil, nil
+}
+
+// opaqueError is a decoded error of a type that is not known to this package.
+// It has the same error string as the original error, and wraps the same
+// errors.
+type opaqueError struct {
+	message string
+	wrapped error
+	errors  []error
+}
+
+// Error implements error.
+func (o *opaqueError) Error() string {
+	return o.message
+}
+
+// Unwrap returns the error wrapped by the original error, if any.
+func (o *opaqueError) Unwrap() error {
+	return o.wrapped
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (o *opaqueError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, o)
+}
+
+// multiOpaqueError is a decoded error of a type that is not known to this
+// package that joined several errors, such as one created by errors.Join in
+// the standard library.
+type multiOpaqueError struct {
+	*opaqueError
+}
+
+// Unwrap returns the errors joined by the original error.
+func (m *multiOpaqueError) Unwrap() []error {
+	return m.errors
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (m *multiOpaqueError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, m)
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type jsonSuite struct{}
+
+var _ = gc.Suite(&jsonSuite{})
+
+// roundTripJSON encodes and decodes err, checking that the decoded error
+// renders in the same way as err and satisfies the same kinds.
+func roundTripJSON(c *gc.C, err error) error {
+	data, encodeErr := errors.EncodeJSON(err)
+	c.Assert(encodeErr, gc.IsNil)
+	decoded, decodeErr := errors.DecodeJSON(data)
+	c.Assert(decodeErr, gc.IsNil)
+	if err == nil {
+		c.Assert(decoded, gc.IsNil)
+		return nil
+	}
+
+	remote, ok := decoded.(*errors.RemoteError)
+	c.Assert(ok, gc.Equals, true)
+	c.Check(remote.Origin, gc.Equals, errors.LocalOrigin())
+	c.Check(decoded.Error(), gc.Equals, err.Error())
+	c.Check(errors.ErrorStack(decoded), gc.Equals, remoteStack(err))
+	c.Check(errors.Details(decoded), gc.Equals, remoteDetails(err))
+	for _, errInfo := range allErrors {
+		c.Check(errors.Is(decoded, errInfo.errType), gc.Equals, errors.Is(err, errInfo.errType),
+			gc.Commentf("Is(err, %s)", errInfo.errName))
+	}
+	c.Check(errors.Is(decoded, errors.Panic), gc.Equals, errors.Is(err, errors.Panic))
+	return decoded
+}
+
+// remoteStack returns the ErrorStack of err as shown for a RemoteError
+// holding err.
+func remoteStack(err error) string {
+	var lines []string
+	if errors.TraceID(err) != "" {
+		lines = append(lines, strings.SplitN(errors.ErrorStack(err), "\n", 2)[0])
+	}
+	for _, frame := range errors.Frames(err) {
+		frame.Remote = true
+		lines = append(lines, frame.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// remoteDetails returns the Details of err as shown for a RemoteError
+// holding err.
+func remoteDetails(err error) string {
+	details := strings.Replace(errors.Details(err), "[{", "[{remote: ", 1)
+	return strings.ReplaceAll(details, "} {", "} {remote: ")
+}
+
+func (*jsonSuite) TestRoundTrip(c *gc.C) {
+	for i, test := range []struct {
+		message   string
+		generator func() error
+	}{{
+		message:   "nil",
+		generator: func() error { return nil },
+	}, {
+		message:   "new",
+		generator: func() error { return errors.New("first error") },
+	}, {
+		message: "traced and annotated",
+		generator: func() error {
+			err := errors.Errorf("first %s", "error")
+			err = errors.Trace(err)
+			err = errors.Annotate(err, "some context")
+			return errors.Annotatef(err, "more %s", "context")
+		},
+	}, {
+		message: "wrapped and masked",
+		generator: func() error {
+			err := newNonComparableError("first error")
+			err = errors.Trace(err)
+			err = errors.Wrap(err, newError("value error"))
+			err = errors.Maskf(err, "masked")
+			err = errors.Annotate(err, "more context")
+			return errors.Trace(err)
+		},
+	}, {
+		message: "wrapped with kind",
+		generator: func() error {
+			err := errors.New("first error")
+			err = errors.Wrapf(err, errors.NotFound, "cannot find %q", "foo")
+			return errors.Mask(err)
+		},
+	}, {
+		message: "typed errors",
+		generator: func() error {
+			err := errors.NotFoundf("unit %q", "mysql/0")
+			err = errors.NewNotValid(err, "bad unit")
+			err = errors.Annotate(err, "cannot deploy")
+			return errors.WithType(err, errors.ConstError("custom"))
+		},
+	}, {
+		message: "hidden kind",
+		generator: func() error {
+			return errors.Trace(errors.Unauthorizedf("token expired"))
+		},
+	}, {
+		message: "wrap constructor without error",
+		generator: func() error {
+			return errors.NewForbidden(nil, "go away")
+		},
+	}, {
+		message: "set location",
+		generator: func() error {
+			return errors.SetLocation(fmt.Errorf("wrapped: %w", errors.New("inner")), 0)
+		},
+	}, {
+		message: "joined",
+		generator: func() error {
+			err := stderrors.Join(errors.NotFoundf("unit"), errors.Timeoutf("connecting"))
+			return errors.Annotate(err, "cannot deploy")
+		},
+	}, {
+		message: "panic",
+		generator: func() error {
+			return errors.Recover(func() error { panic("boom") })
+		},
+	}, {
+		message: "annotated panic with an error value",
+		generator: func() error {
+			err := errors.Recover(func() error { panic(errors.NotFoundf("unit")) })
+			return errors.Annotate(err, "cannot deploy")
+		},
+	}, {
+		message: "secrets",
+		generator: func() error {
+			return errors.Annotatef(errors.New("boom"), "login %s failed", errors.Secret("bob"))
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		roundTripJSON(c, test.generator())
+	}
+}
+
+func (*jsonSuite) TestRoundTripAttachments(c *gc.C) {
+	errors.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "4bf92f35", "00f067aa", true
+	})
+	defer errors.SetTraceExtractor(nil)
+	previous := errors.SetPolicy(errors.Policy{RecordTime: true})
+	defer errors.SetPolicy(previous)
+
+	info := errors.ErrorInfo{Reason: "UNIT_NOT_FOUND", Domain: "juju", Metadata: map[string]string{"unit": "mysql/0"}}
+	err := errors.NotFoundf("unit")
+	err = errors.WithErrorInfo(err, info)
+	err = errors.With(err, "unit", "mysql/0", "attempt", 2, "password", errors.Secret("hunter2"))
+	err = errors.WithUserMessage(err, "the unit was not found")
+	err = errors.WithHint(err, "run juju status")
+	err = errors.WithHelpURL(err, "https://juju.is/docs")
+	err = errors.WithTraceContext(context.Background(), err)
+	err = errors.WithValue(err, 42)
+
+	decoded := roundTripJSON(c, err)
+	obtained, ok := errors.Info(decoded)
+	c.Check(ok, gc.Equals, true)
+	c.Check(obtained, gc.DeepEquals, info)
+	c.Check(errors.Fields(decoded), gc.DeepEquals, []errors.Field{
+		{Key: "unit", Value: "mysql/0"},
+		{Key: "attempt", Value: float64(2)},
+		{Key: "password", Value: "<redacted>"},
+	})
+	c.Check(errors.UserMessage(decoded), gc.Equals, "the unit was not found")
+	c.Check(errors.Hints(decoded), gc.DeepEquals, errors.Hints(err))
+	c.Check(errors.TraceID(decoded), gc.Equals, "4bf92f35")
+	c.Check(errors.SpanID(decoded), gc.Equals, "00f067aa")
+	_, ok = errors.Value[int](decoded)
+	c.Check(ok, gc.Equals, false)
+
+	frames, decodedFrames := errors.Frames(err), errors.Frames(decoded)
+	c.Assert(decodedFrames, gc.HasLen, len(frames))
+	for i := range frames {
+		c.Check(decodedFrames[i].Time.Equal(frames[i].Time), gc.Equals, true)
+	}
+}
+
+func (*jsonSuite) TestDecodeInvalid(c *gc.C) {
+	_, err := errors.DecodeJSON([]byte("not json"))
+	c.Check(errors.Is(err, errors.NotValid), gc.Equals, true)
+
+	_, err = errors.DecodeJSON([]byte(`{"version": 99}`))
+	c.Check(err, gc.ErrorMatches, "error encoding version 99 not supported")
+	c.Check(errors.Is(err, errors.NotSupported), gc.Equals, true)
+
+	_, err = errors.DecodeJSON([]byte(`{"version": 1, "error": {"type": "bogus"}}`))
+	c.Check(err, gc.ErrorMatches, `error type "bogus" not valid`)
+}
+
+func (*jsonSuite) TestSchema(c *gc.C) {
+	previous := errors.LocalOrigin()
+	errors.SetLocalOrigin(errors.Origin{Service: "juju", Host: "host1"})
+	defer errors.SetLocalOrigin(previous)
+
+	err := errors.Wrap(errors.New("first"), errors.NotFound)
+	data, encodeErr := errors.EncodeJSON(errors.Trace(err))
+	c.Assert(encodeErr, gc.IsNil)
+	c.Assert(string(data), gc.Matches, `\{"version":1,"origin":\{"service":"juju","host":"host1"\},"error":\{"type":"err","function":".*","line":\d+,`+
+		`"wrapped":\{"type":"err","function":".*","line":\d+,`+
+		`"wrapped":\{"type":"err","message":"first","function":".*","line":\d+\},`+
+		`"cause":\{"type":"const","message":"not found"\}\},"causeInherited":true\}\}`)
+}
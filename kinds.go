@@ -0,0 +1,118 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	kindsMutex sync.RWMutex
+
+	// kinds holds the known kinds in the order they were registered,
+	// starting with the error types of this package.
+	kinds = []ConstError{
+		Timeout,
+		NotFound,
+		UserNotFound,
+		Unauthorized,
+		NotImplemented,
+		AlreadyExists,
+		NotSupported,
+		NotValid,
+		NotProvisioned,
+		NotAssigned,
+		BadRequest,
+		MethodNotAllowed,
+		Forbidden,
+		QuotaLimitExceeded,
+		NotYetAvailable,
+		Panic,
+	}
+
+	// kindsByName maps the normalized name of each known kind to the kind.
+	kindsByName = make(map[string]ConstError)
+)
+
+func init() {
+	for _, kind := range kinds {
+		kindsByName[kindName(string(kind))] = kind
+	}
+}
+
+// kindName normalizes the name of a kind so that "not found", "NotFound",
+// "not_found" and "NOT-FOUND" all refer to NotFound.
+func kindName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// RegisterKind registers kind so that it is known to ParseKind and listed by
+// KnownKinds. This allows kinds defined outside of this package to be used in
+// configuration files, JSON fields and flags.
+//
+// An error satisfying AlreadyExists is returned if a kind with the same name
+// has already been registered, and one satisfying NotValid if the name of
+// kind is empty.
+func RegisterKind(kind ConstError) error {
+	name := kindName(string(kind))
+	if name == "" {
+		return NotValidf("empty kind")
+	}
+	kindsMutex.Lock()
+	defer kindsMutex.Unlock()
+	if existing, ok := kindsByName[name]; ok {
+		return AlreadyExistsf("kind %q (registered as %q)", string(kind), string(existing))
+	}
+	kindsByName[name] = kind
+	kinds = append(kinds, kind)
+	return nil
+}
+
+// ParseKind returns the known kind named by s, which is the text of the kind,
+// such as "not found", or the same words in another case or joined by
+// underscores or hyphens, such as "NotFound" or "NOT_FOUND". The known kinds
+// are the error types of this package and the kinds registered with
+// RegisterKind. An error satisfying NotValid is returned if s does not name a
+// known kind.
+func ParseKind(s string) (ConstError, error) {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+	kind, ok := kindsByName[kindName(s)]
+	if !ok {
+		return "", NotValidf("kind %q", s)
+	}
+	return kind, nil
+}
+
+// KnownKinds returns the error types of this package followed by the kinds
+// registered with RegisterKind, in the order they were registered.
+func KnownKinds() []ConstError {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+	return append([]ConstError(nil), kinds...)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the text of the
+// kind.
+func (e ConstError) MarshalText() ([]byte, error) {
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting e to the known
+// kind named by text, as accepted by ParseKind.
+func (e *ConstError) UnmarshalText(text []byte) error {
+	kind, err := ParseKind(string(text))
+	if err != nil {
+		return Trace(err)
+	}
+	*e = kind
+	return nil
+}
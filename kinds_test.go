@@ -0,0 +1,90 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"encoding/json"
+	"flag"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type kindsSuite struct{}
+
+var _ = gc.Suite(&kindsSuite{})
+
+func (*kindsSuite) TestParseKind(c *gc.C) {
+	for _, s := range []string{"not found", "NotFound", "not_found", "NOT-FOUND", " Not Found "} {
+		kind, err := errors.ParseKind(s)
+		c.Check(err, gc.IsNil)
+		c.Check(kind, gc.Equals, errors.NotFound, gc.Commentf("ParseKind(%q)", s))
+	}
+	for _, errInfo := range allErrors {
+		kind, err := errors.ParseKind(errInfo.errType.Error())
+		c.Check(err, gc.IsNil)
+		c.Check(kind, gc.Equals, errInfo.errType)
+	}
+
+	_, err := errors.ParseKind("bogus")
+	c.Assert(err, gc.ErrorMatches, `kind "bogus" not valid`)
+	c.Assert(errors.Is(err, errors.NotValid), gc.Equals, true)
+}
+
+func (*kindsSuite) TestRegisterKind(c *gc.C) {
+	const outOfCapacity = errors.ConstError("out of capacity")
+	c.Assert(errors.RegisterKind(outOfCapacity), gc.IsNil)
+	defer errors.UnregisterKind(outOfCapacity)
+
+	kind, err := errors.ParseKind("OutOfCapacity")
+	c.Assert(err, gc.IsNil)
+	c.Assert(kind, gc.Equals, outOfCapacity)
+	known := errors.KnownKinds()
+	c.Assert(known[0], gc.Equals, errors.Timeout)
+	c.Assert(known[len(known)-1], gc.Equals, outOfCapacity)
+
+	err = errors.RegisterKind("OUT_OF_CAPACITY")
+	c.Assert(err, gc.ErrorMatches, `kind "OUT_OF_CAPACITY" \(registered as "out of capacity"\) already exists`)
+	c.Assert(errors.Is(err, errors.AlreadyExists), gc.Equals, true)
+	err = errors.RegisterKind(" ")
+	c.Assert(errors.Is(err, errors.NotValid), gc.Equals, true)
+}
+
+func (*kindsSuite) TestKnownKinds(c *gc.C) {
+	known := errors.KnownKinds()
+	isKnown := make(map[errors.ConstError]bool)
+	for _, kind := range known {
+		isKnown[kind] = true
+	}
+	for _, errInfo := range allErrors {
+		c.Check(isKnown[errInfo.errType], gc.Equals, true, gc.Commentf("%s", errInfo.errName))
+	}
+	known[0] = "changed"
+	c.Assert(errors.KnownKinds()[0], gc.Equals, errors.Timeout)
+}
+
+func (*kindsSuite) TestText(c *gc.C) {
+	var config struct {
+		Kind  errors.ConstError   `json:"kind"`
+		Kinds []errors.ConstError `json:"kinds"`
+	}
+	err := json.Unmarshal([]byte(`{"kind": "NotFound", "kinds": ["timeout", "not valid"]}`), &config)
+	c.Assert(err, gc.IsNil)
+	c.Assert(config.Kind, gc.Equals, errors.NotFound)
+	c.Assert(config.Kinds, gc.DeepEquals, []errors.ConstError{errors.Timeout, errors.NotValid})
+
+	data, err := json.Marshal(config)
+	c.Assert(err, gc.IsNil)
+	c.Assert(string(data), gc.Equals, `{"kind":"not found","kinds":["timeout","not valid"]}`)
+
+	err = json.Unmarshal([]byte(`{"kind": "bogus"}`), &config)
+	c.Assert(err, gc.ErrorMatches, `kind "bogus" not valid`)
+
+	kind := errors.Timeout
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.TextVar(&kind, "kind", errors.Timeout, "the kind")
+	c.Assert(flags.Parse([]string{"-kind", "quota-limit-exceeded"}), gc.IsNil)
+	c.Assert(kind, gc.Equals, errors.QuotaLimitExceeded)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// minChainDepth is the smallest effective MaxChainDepth.
+	minChainDepth = 2
+
+	// minErrorLength is the smallest effective MaxErrorLength, which leaves
+	// room for the short elision marker and a character on each side of it.
+	minErrorLength = len(shortElisionMarker) + 2*utf8.UTFMax
+
+	// shortElisionMarker replaces the middle of strings when the limit is
+	// too small for the marker stating the number of bytes elided.
+	shortElisionMarker = "…"
+)
+
+// foldedLayers is the attachment of the layer that takes the place of the
+// layers folded by limitDepth.
+type foldedLayers struct {
+	count int
+}
+
+// limitDepth returns e, which has just been added to an error chain by Trace
+// or an Annotate function, or an equivalent of it with a chain no deeper than
+// the maximum depth of the policy. The older of the consecutive layers added
+// by those functions at the top of the chain are folded into a single layer
+// stating how many were folded, which counts towards the depth. Other layers,
+// such as those added by Wrap or With, are kept.
+func limitDepth(e *Err) error {
+	maxDepth := CurrentPolicy().MaxChainDepth
+	if maxDepth <= 0 {
+		return e
+	}
+	// The folded layer takes the place of all but the newest layer.
+	if maxDepth < minChainDepth {
+		maxDepth = minChainDepth
+	}
+	var layers []*Err
+	var base error = e
+	for {
+		layer, ok := base.(*Err)
+		if !ok || !isAnnotation(layer) {
+			break
+		}
+		layers = append(layers, layer)
+		base = layer.previous
+	}
+	// A layer of folded layers counts as one layer, and is merged into the
+	// new one.
+	depth, count := len(layers), 0
+	if folded, ok := base.(*Err); ok {
+		if f, ok := folded.attachment.(foldedLayers); ok {
+			depth++
+			count = f.count
+			base = folded.previous
+		}
+	}
+	if depth <= maxDepth {
+		return e
+	}
+
+	keep := maxDepth - 1
+	count += len(layers) - keep
+	top := layers[keep]
+	var result error = &Err{
+		message:    fmt.Sprintf("(%d layers folded)", count),
+		previous:   base,
+		cause:      Cause(base),
+		function:   top.function,
+		line:       top.line,
+		time:       top.time,
+		attachment: foldedLayers{count: count},
+	}
+	for i := keep - 1; i >= 0; i-- {
+		layer := *layers[i]
+		layer.previous = result
+		result = &layer
+	}
+	return result
+}
+
+// isAnnotation reports whether err is a layer added by Trace or Annotate,
+// which only adds a location and possibly a message to the error it holds.
+func isAnnotation(err error) bool {
+	e, ok := err.(*Err)
+	return ok && e.previous != nil && e.attachment == nil && sameError(Cause(e.previous), e.cause)
+}
+
+// limitLength returns s with its middle elided so that it is no longer than
+// the maximum length of the policy.
+func limitLength(s string) string {
+	return elide(s, CurrentPolicy().MaxErrorLength)
+}
+
+// limitsLength reports whether the Error method of err limits its length.
+// Such errors are rendered with renderText when they are part of the error
+// string of another error, so that the limit is only applied once, to the
+// whole error string.
+func limitsLength(err error) bool {
+	switch err.(type) {
+	case *Err, *locationError, *errWithType, *messageError, *RemoteError, *MultiError, *PanicError:
+		return true
+	}
+	return false
+}
+
+// elide returns s shortened to at most max bytes if it is longer and max is
+// positive, by replacing the middle of s with a marker stating the number of
+// bytes removed, or with "…" if max is too small for that marker. The
+// beginning and the end of an error string or stack are usually the most
+// useful parts: the outermost annotation and the cause. A max smaller than
+// minErrorLength is treated as minErrorLength.
+func elide(s string, max int) string {
+	if max > 0 && max < minErrorLength {
+		max = minErrorLength
+	}
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	// The marker for all of s is at least as long as the final marker.
+	keep := max - len(elisionMarker(len(s)))
+	short := keep <= 0
+	if short {
+		keep = max - len(shortElisionMarker)
+	}
+	head := keep - keep/2
+	tail := len(s) - keep/2
+	for head > 0 && !utf8.RuneStart(s[head]) {
+		head--
+	}
+	for tail < len(s) && !utf8.RuneStart(s[tail]) {
+		tail++
+	}
+	if short {
+		return s[:head] + shortElisionMarker + s[tail:]
+	}
+	return s[:head] + elisionMarker(tail-head) + s[tail:]
+}
+
+// elisionMarker returns the text that replaces n elided bytes.
+func elisionMarker(n int) string {
+	return fmt.Sprintf("...[%d bytes elided]...", n)
+}
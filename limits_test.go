@@ -0,0 +1,151 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type limitsSuite struct {
+	previous errors.Policy
+}
+
+var _ = gc.Suite(&limitsSuite{})
+
+func (s *limitsSuite) SetUpTest(c *gc.C) {
+	s.previous = errors.CurrentPolicy()
+}
+
+func (s *limitsSuite) TearDownTest(c *gc.C) {
+	errors.SetPolicy(s.previous)
+}
+
+func (*limitsSuite) TestMaxErrorLength(c *gc.C) {
+	err := errors.Annotate(errors.NotFoundf("unit"), strings.Repeat("x", 100))
+	errors.SetPolicy(errors.Policy{MaxErrorLength: 50})
+
+	message := err.Error()
+	c.Assert(len(message) <= 50, gc.Equals, true)
+	c.Assert(message, gc.Equals, "xxxxxxxxxxxxx...[90 bytes elided]...nit not found")
+	c.Assert(len(errors.ErrorStack(err)) <= 50, gc.Equals, true)
+	c.Assert(errors.ErrorStack(err), gc.Matches, `github\.com/.*\.\.\.\[\d+ bytes elided\]\.\.\.x+`)
+	c.Assert(len(errors.Details(err)) <= 50, gc.Equals, true)
+
+	// Short strings are unchanged.
+	c.Assert(errors.NotFoundf("unit").Error(), gc.Equals, "unit not found")
+
+	// Multi-byte characters are not split.
+	err = errors.New(strings.Repeat("é", 40))
+	message = err.Error()
+	c.Assert(len(message) <= 50, gc.Equals, true)
+	c.Assert(strings.ToValidUTF8(message, "?"), gc.Equals, message)
+
+	// A limit too small for the marker uses a shorter one.
+	errors.SetPolicy(errors.Policy{MaxErrorLength: 10})
+	c.Assert(errors.New("héllo wörld").Error(), gc.Equals, "hél…rld")
+	c.Assert(err.Error(), gc.Equals, "éé…éé")
+
+	// Smaller limits are raised to the minimum.
+	errors.SetPolicy(errors.Policy{MaxErrorLength: 1})
+	c.Assert(err.Error(), gc.Equals, "éé…éé")
+	c.Assert(errors.New("héllo").Error(), gc.Equals, "héllo")
+}
+
+func (*limitsSuite) TestMaxErrorLengthAppliedOnce(c *gc.C) {
+	err := errors.New(strings.Repeat("a", 100))
+	err = errors.Annotate(err, strings.Repeat("b", 100))
+	err = errors.Annotate(err, strings.Repeat("c", 100))
+	errors.SetPolicy(errors.Policy{MaxErrorLength: 60})
+
+	message := err.Error()
+	c.Assert(message, gc.Equals, "cccccccccccccccccc...[268 bytes elided]...aaaaaaaaaaaaaaaaaa")
+	c.Assert(len(message) <= 60, gc.Equals, true)
+}
+
+func (*limitsSuite) TestMaxErrorLengthTypedErrors(c *gc.C) {
+	errors.SetPolicy(errors.Policy{MaxErrorLength: 60})
+	long := strings.Repeat("x", 200)
+	for _, err := range []error{
+		errors.NotFoundf("%s", long),
+		errors.NewNotValid(nil, long),
+		errors.WithType(errors.New(long), errors.NotValid),
+		errors.NewRemoteError(errors.New(long), errors.Origin{}),
+	} {
+		c.Check(len(err.Error()) <= 60, gc.Equals, true, gc.Commentf("%T: %s", err, err))
+		c.Check(err.Error(), gc.Matches, `x+\.\.\.\[\d+ bytes elided\]\.\.\..*`)
+	}
+}
+
+func (*limitsSuite) TestMaxChainDepth(c *gc.C) {
+	errors.SetPolicy(errors.Policy{MaxChainDepth: 4})
+	notFound := errors.NotFoundf("unit")
+	err := notFound
+	for i := 0; i < 10; i++ {
+		err = errors.Annotate(err, "attempt failed")
+		err = errors.Trace(err)
+	}
+	c.Assert(err.Error(), gc.Equals, "attempt failed: (17 layers folded): unit not found")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Cause(err), gc.Equals, errors.Cause(notFound))
+
+	// The chain holds four layers above the original error.
+	c.Assert(chainDepth(err)-chainDepth(notFound), gc.Equals, 4)
+}
+
+func chainDepth(err error) int {
+	depth := 0
+	for ; err != nil; err = errors.Unwrap(err) {
+		depth++
+	}
+	return depth
+}
+
+func (*limitsSuite) TestMaxChainDepthKeepsOtherLayers(c *gc.C) {
+	errors.SetPolicy(errors.Policy{MaxChainDepth: 2})
+	err := errors.Wrap(errors.New("first"), errors.New("second"))
+	err = errors.With(err, "unit", "mysql/0")
+	for i := 0; i < 5; i++ {
+		err = errors.Trace(err)
+	}
+	err = errors.Annotate(err, "cannot deploy")
+	c.Assert(err.Error(), gc.Equals, "cannot deploy: (5 layers folded): second")
+	c.Assert(errors.Fields(err), gc.HasLen, 1)
+	c.Assert(errors.Details(err), gc.Matches, `\[\{.*: cannot deploy\} \{.*: \(5 layers folded\)\} \{.*\} \{.*\} \{.*: first\}\]`)
+}
+
+func (*limitsSuite) TestMaxChainDepthMinimum(c *gc.C) {
+	errors.SetPolicy(errors.Policy{MaxChainDepth: 1})
+	notFound := errors.NotFoundf("unit")
+	err := notFound
+	for i := 0; i < 5; i++ {
+		err = errors.Annotate(err, "attempt failed")
+	}
+	c.Assert(err.Error(), gc.Equals, "attempt failed: (4 layers folded): unit not found")
+	c.Assert(chainDepth(err)-chainDepth(notFound), gc.Equals, 2)
+}
+
+func (*limitsSuite) TestMaxChainDepthDeferredAnnotatef(c *gc.C) {
+	errors.SetPolicy(errors.Policy{MaxChainDepth: 3})
+	notFound := errors.NotFoundf("unit")
+	err := notFound
+	for i := 0; i < 10; i++ {
+		func() {
+			defer errors.DeferredAnnotatef(&err, "attempt failed")
+		}()
+	}
+	c.Assert(err.Error(), gc.Equals, "attempt failed: attempt failed: (8 layers folded): unit not found")
+	c.Assert(chainDepth(err)-chainDepth(notFound), gc.Equals, 3)
+}
+
+func (*limitsSuite) TestMaxChainDepthDisabled(c *gc.C) {
+	err := errors.New("boom")
+	for i := 0; i < 10; i++ {
+		err = errors.Trace(err)
+	}
+	c.Assert(errors.Details(err), gc.Matches, `\[(\{[^}]*\} ?){11}\]`)
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+// Catalog holds translations of error messages and kinds, keyed by language.
+//
+// Messages are identified by the format string they were created with, such
+// as the format passed to Errorf, Annotatef or NotFoundf, or by the message
+// itself for constant messages such as those passed to New, Annotate or
+// WithUserMessage. A translated template is formatted with the original
+// arguments, so it must use the same verbs; explicit argument indexes such as
+// %[2]s may be used to reorder them. Arguments other than strings, numbers,
+// booleans and values marked by Secret are kept by errors only as the text
+// they were originally formatted to, which a template includes as it is
+// whatever verb it uses. Kinds are translated by the ConstError itself, as
+// they are rendered by the constructors such as NotFoundf.
+//
+// Languages are BCP 47 tags such as "fr" or "pt-BR". Lookups for a regional
+// variant fall back to its base language, and then to the original English
+// text.
+//
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+	kinds    map[string]map[ConstError]string
+}
+
+// DefaultCatalog is the catalog used by Localize and LocalizedUserMessage.
+var DefaultCatalog = NewCatalog()
+
+// NewCatalog returns a new, empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages: make(map[string]map[string]string),
+		kinds:    make(map[string]map[ConstError]string),
+	}
+}
+
+// SetMessage sets the template for the message with the given id in lang.
+//
+// For example:
+//
+//	catalog.SetMessage("fr", "cannot deploy %q", "impossible de déployer %q")
+func (c *Catalog) SetMessage(lang, id, template string) {
+	lang = normalizeLang(lang)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[lang] == nil {
+		c.messages[lang] = make(map[string]string)
+	}
+	c.messages[lang][id] = template
+}
+
+// SetKind sets the text of kind in lang.
+//
+// For example:
+//
+//	catalog.SetKind("fr", errors.NotFound, "introuvable")
+func (c *Catalog) SetKind(lang string, kind ConstError, text string) {
+	lang = normalizeLang(lang)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.kinds[lang] == nil {
+		c.kinds[lang] = make(map[ConstError]string)
+	}
+	c.kinds[lang][kind] = text
+}
+
+// message returns the template for the message with the given id in lang,
+// or id itself if there is no translation.
+func (c *Catalog) message(lang, id string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range langFallbacks(lang) {
+		if template, ok := c.messages[l][id]; ok {
+			return template, true
+		}
+	}
+	return id, false
+}
+
+// kind returns the text of kind in lang, or the kind's own text if there is
+// no translation.
+func (c *Catalog) kind(lang string, kind ConstError) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range langFallbacks(lang) {
+		if text, ok := c.kinds[l][kind]; ok {
+			return text
+		}
+	}
+	return string(kind)
+}
+
+// Localize returns the error string of err rendered in lang using the
+// translations in c. Each annotation in the chain is rendered with its
+// translated template and original arguments, falling back to the English
+// text when there is no translation. Values marked by Secret remain
+// redacted. The empty string is returned if err is nil.
+func (c *Catalog) Localize(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+	o := renderOptions(err)
+	o.catalog, o.lang = c, lang
+	return errorString(err, o)
+}
+
+// UserMessage returns the result of UserMessage for err, translated into lang
+// using the translations in c.
+func (c *Catalog) UserMessage(err error, lang string) string {
+	msg := UserMessage(err)
+	if msg == "" {
+		return ""
+	}
+	template, _ := c.message(lang, msg)
+	return template
+}
+
+// Localize returns the error string of err rendered in lang using
+// DefaultCatalog. See Catalog.Localize.
+func Localize(err error, lang string) string {
+	return DefaultCatalog.Localize(err, lang)
+}
+
+// LocalizedUserMessage returns the user message of err translated into lang
+// using DefaultCatalog. See Catalog.UserMessage.
+func LocalizedUserMessage(err error, lang string) string {
+	return DefaultCatalog.UserMessage(err, lang)
+}
+
+// normalizeLang returns lang in a canonical form for lookups, so that tags
+// such as "pt_BR.UTF-8" and "pt-br" are equivalent.
+func normalizeLang(lang string) string {
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
+
+// langFallbacks returns the languages to look up for lang, from the most to
+// the least specific.
+func langFallbacks(lang string) []string {
+	lang = normalizeLang(lang)
+	fallbacks := []string{lang}
+	for {
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			return fallbacks
+		}
+		lang = lang[:i]
+		fallbacks = append(fallbacks, lang)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	stderrors "errors"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type localizeSuite struct{}
+
+var _ = gc.Suite(&localizeSuite{})
+
+// unitTag is a Stringer used as a formatted argument.
+type unitTag struct {
+	name string
+}
+
+func (t unitTag) String() string {
+	return "unit-" + t.name
+}
+
+func frenchCatalog() *errors.Catalog {
+	catalog := errors.NewCatalog()
+	catalog.SetKind("fr", errors.NotFound, "introuvable")
+	catalog.SetMessage("fr", "unit %q", "unité %q")
+	catalog.SetMessage("fr", "cannot deploy %s to %s", "impossible de déployer %[2]s sur %[1]s")
+	catalog.SetMessage("fr", "connection lost", "connexion perdue")
+	catalog.SetMessage("fr", "cannot deploy %v", "impossible de déployer %v")
+	catalog.SetMessage("fr", "the requested resource was not found", "la ressource demandée est introuvable")
+	return catalog
+}
+
+func (*localizeSuite) TestLocalize(c *gc.C) {
+	catalog := frenchCatalog()
+	for i, test := range []struct {
+		err      error
+		lang     string
+		expected string
+	}{{
+		err:      errors.NotFoundf("unit %q", "mysql/0"),
+		lang:     "fr",
+		expected: `unité "mysql/0" introuvable`,
+	}, {
+		err:      errors.Annotatef(errors.NotFoundf("unit %q", "mysql/0"), "cannot deploy %s to %s", "mysql", "lxd"),
+		lang:     "fr-CA",
+		expected: `impossible de déployer lxd sur mysql: unité "mysql/0" introuvable`,
+	}, {
+		err:      errors.Trace(errors.New("connection lost")),
+		lang:     "fr_FR.UTF-8",
+		expected: "connexion perdue",
+	}, {
+		err:      errors.NewNotFound(stderrors.New("no such row"), "connection lost"),
+		lang:     "fr",
+		expected: "connexion perdue: no such row",
+	}, {
+		err:      errors.Annotatef(errors.NotFoundf("unit %q", "mysql/0"), "untranslated %d", 42),
+		lang:     "fr",
+		expected: `untranslated 42: unité "mysql/0" introuvable`,
+	}, {
+		err:      errors.Annotatef(errors.NotFoundf("unit %q", "mysql/0"), "cannot deploy %s to %s", "mysql", "lxd"),
+		lang:     "de",
+		expected: `cannot deploy mysql to lxd: unit "mysql/0" not found`,
+	}, {
+		err:      errors.Annotatef(errors.New("connection lost"), "cannot deploy %s to %s", errors.Secret("mysql"), "lxd"),
+		lang:     "fr",
+		expected: "impossible de déployer lxd sur <redacted>: connexion perdue",
+	}, {
+		err:      errors.Annotatef(errors.New("connection lost"), "cannot deploy %v", unitTag{"mysql-0"}),
+		lang:     "fr",
+		expected: "impossible de déployer unit-mysql-0: connexion perdue",
+	}, {
+		err:      errors.NotFoundf("cannot deploy %v", unitTag{"mysql-0"}),
+		lang:     "fr",
+		expected: "impossible de déployer unit-mysql-0 introuvable",
+	}} {
+		c.Logf("test %d: %v", i, test.err)
+		c.Check(catalog.Localize(test.err, test.lang), gc.Equals, test.expected)
+	}
+	c.Check(catalog.Localize(nil, "fr"), gc.Equals, "")
+}
+
+func (*localizeSuite) TestLocalizeKeepsError(c *gc.C) {
+	err := errors.Annotatef(errors.NotFoundf("unit %q", "mysql/0"), "cannot deploy %s to %s", "mysql", "lxd")
+	frenchCatalog().Localize(err, "fr")
+	c.Assert(err.Error(), gc.Equals, `cannot deploy mysql to lxd: unit "mysql/0" not found`)
+}
+
+func (*localizeSuite) TestUserMessage(c *gc.C) {
+	catalog := frenchCatalog()
+	err := errors.NotFoundf("unit %q", "mysql/0")
+	c.Check(catalog.UserMessage(err, "fr"), gc.Equals, "la ressource demandée est introuvable")
+	c.Check(catalog.UserMessage(err, "de"), gc.Equals, "the requested resource was not found")
+	c.Check(catalog.UserMessage(errors.WithUserMessage(err, "connection lost"), "fr"), gc.Equals, "connexion perdue")
+	c.Check(catalog.UserMessage(nil, "fr"), gc.Equals, "")
+}
+
+func (*localizeSuite) TestDefaultCatalog(c *gc.C) {
+	errors.DefaultCatalog.SetKind("x-test", errors.NotValid, "no es válido")
+	errors.DefaultCatalog.SetMessage("x-test", "the request is not valid", "la solicitud no es válida")
+
+	err := errors.NotValidf("config")
+	c.Check(errors.Localize(err, "x-test"), gc.Equals, "config no es válido")
+	c.Check(errors.LocalizedUserMessage(err, "x-test"), gc.Equals, "la solicitud no es válida")
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// PanicError is the error that a recovered panic is converted to by Recover
+// and DeferredRecover. It satisfies Is(err, Panic), and also the errors the
+// panic value satisfies if it is an error. It is rendered as "panic: " followed
+// by the panic value, and the stack of the goroutine that panicked follows the
+// output of ErrorStack and %+v for any error with a PanicError in its chain.
+type PanicError struct {
+	Err
+
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Stack is the stack of the goroutine that panicked at the time of the
+	// panic, as formatted by runtime/debug.Stack.
+	Stack []byte
+}
+
+// newPanicError returns a PanicError for value, recovered at the given
+// location.
+func newPanicError(value interface{}, function string, line int) *PanicError {
+	p := &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+	p.message = fmt.Sprintf("panic: %v", value)
+	p.function, p.line = function, line
+	p.time = recordTime()
+	return p
+}
+
+// Is reports whether target is Panic.
+func (p *PanicError) Is(target error) bool {
+	return target == Panic
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and
+// errors.As match it.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack, followed by the stack of the goroutine that panicked.
+func (p *PanicError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, p)
+}
+
+var (
+	unrecoverableMutex sync.RWMutex
+
+	// unrecoverable holds the errors that are not converted to errors when
+	// they are the value of a panic.
+	unrecoverable = []error{http.ErrAbortHandler}
+)
+
+// RegisterUnrecoverable registers target as an error that Recover and
+// DeferredRecover must not swallow: a panic with a value satisfying Is(value,
+// target) is not converted to an error but panics again. Errors used to abort
+// by panicking, such as http.ErrAbortHandler, which is registered by default,
+// are usually registered.
+func RegisterUnrecoverable(target error) {
+	unrecoverableMutex.Lock()
+	defer unrecoverableMutex.Unlock()
+	unrecoverable = append(unrecoverable, target)
+}
+
+// mustRepanic reports whether a panic with value must continue rather than
+// be converted to an error.
+func mustRepanic(value interface{}) bool {
+	err, ok := value.(error)
+	if !ok {
+		return false
+	}
+	if _, ok := err.(runtime.Error); ok && CurrentPolicy().RepanicRuntimeErrors {
+		return true
+	}
+	unrecoverableMutex.RLock()
+	defer unrecoverableMutex.RUnlock()
+	for _, target := range unrecoverable {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// goexitMessage is the panic value of the PanicError raised when a function
+// called by Recover calls runtime.Goexit and PanicOnGoexit is set by Policy.
+const goexitMessage = "runtime.Goexit called"
+
+// Recover calls fn and returns its error, or a PanicError satisfying
+// Is(err, Panic) if fn panics. The PanicError records the location of the
+// Recover call, the panic value and the stack of the goroutine at the time of
+// the panic.
+//
+// Panics with values that must not be swallowed, as registered with
+// RegisterUnrecoverable or selected by Policy, are not recovered. A call to
+// runtime.Goexit by fn cannot be recovered either: the goroutine exits, unless
+// Policy selects PanicOnGoexit.
+//
+// For example:
+//
+//	err := errors.Recover(func() error {
+//	    return plugin.Run(ctx)
+//	})
+func Recover(fn func() error) error {
+	function, line := getLocation(1)
+	_, err := recoverAt(fn, function, line)
+	return err
+}
+
+// recoverAt implements Recover, recording the given location in the
+// PanicError. It also reports whether fn panicked.
+func recoverAt(fn func() error, function string, line int) (panicked bool, err error) {
+	returned := false
+	defer func() {
+		if returned {
+			return
+		}
+		value := recover()
+		if value == nil {
+			// Since Go 1.21, panic(nil) panics with a *runtime.PanicNilError
+			// so fn has called runtime.Goexit.
+			if CurrentPolicy().PanicOnGoexit {
+				panic(newPanicError(goexitMessage, function, line))
+			}
+			return
+		}
+		if mustRepanic(value) {
+			panic(value)
+		}
+		panicked, err = true, newPanicError(value, function, line)
+	}()
+	err = fn()
+	returned = true
+	return false, err
+}
+
+// DeferredRecover recovers from a panic in the function that defers it, and
+// sets *err to a PanicError satisfying Is(err, Panic). The PanicError records
+// the panic value, the stack of the goroutine at the time of the panic and the
+// location of the panic, as the deferred call has no location of its own. It
+// must be called directly by a deferred statement, for recover to stop the
+// panic. Nothing is done if there is no panic, and the panics that Recover
+// does not recover are not recovered.
+//
+// For example:
+//
+//	func (w *worker) loop() (err error) {
+//	    defer errors.DeferredRecover(&err)
+//	    ...
+//	}
+func DeferredRecover(err *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	if mustRepanic(value) {
+		panic(value)
+	}
+	function, line := panicLocation()
+	*err = newPanicError(value, function, line)
+}
+
+// panicLocation returns the location of the panic being recovered by the
+// caller of panicLocation: the first function that is not part of the
+// runtime.
+func panicLocation() (string, int) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame.Function, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
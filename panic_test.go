@@ -0,0 +1,188 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type panicSuite struct {
+	previous      errors.Policy
+	unrecoverable []error
+}
+
+var _ = gc.Suite(&panicSuite{})
+
+func (s *panicSuite) SetUpTest(c *gc.C) {
+	s.previous = errors.CurrentPolicy()
+	s.unrecoverable = errors.SetUnrecoverable(nil)
+	errors.SetUnrecoverable(s.unrecoverable)
+}
+
+func (s *panicSuite) TearDownTest(c *gc.C) {
+	errors.SetPolicy(s.previous)
+	errors.SetUnrecoverable(s.unrecoverable)
+}
+
+func panicking(value interface{}) {
+	panic(value)
+}
+
+func panicBoom() error {
+	panicking("boom")
+	return nil
+}
+
+func (*panicSuite) TestRecover(c *gc.C) {
+	err := errors.Recover(panicBoom)
+	loc := errorLocationValue(c)
+	c.Assert(err, gc.ErrorMatches, "panic: boom")
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+	c.Assert(errors.StatusCode(err), gc.Equals, int32(13))
+
+	var p *errors.PanicError
+	c.Assert(errors.As(err, &p), gc.Equals, true)
+	c.Assert(p.Value, gc.Equals, "boom")
+	c.Assert(string(p.Stack), gc.Matches, "(?s).*errors_test.panicking.*")
+	stack := loc + ": panic: boom\n\n" + string(p.Stack[:len(p.Stack)-1])
+	c.Assert(errors.ErrorStack(err), gc.Equals, stack)
+	c.Assert(fmt.Sprintf("%+v", err), gc.Equals, stack)
+	c.Assert(errors.Formatter{OmitPanicStacks: true}.String(err), gc.Equals, loc+": panic: boom")
+}
+
+func (*panicSuite) TestAnnotatedPanicStack(c *gc.C) {
+	err := errors.Recover(panicBoom)
+	loc0 := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	loc1 := errorLocationValue(c)
+
+	var p *errors.PanicError
+	c.Assert(errors.As(err, &p), gc.Equals, true)
+	stack := loc0 + ": panic: boom\n" + loc1 + ": cannot deploy\n\n" + string(p.Stack[:len(p.Stack)-1])
+	c.Assert(errors.ErrorStack(err), gc.Equals, stack)
+	c.Assert(fmt.Sprintf("%+v", err), gc.Equals, stack)
+
+	run := func() (err error) {
+		defer errors.DeferredAnnotatef(&err, "cannot deploy")
+		defer errors.DeferredRecover(&err)
+		panicking("boom")
+		return nil
+	}
+	c.Assert(fmt.Sprintf("%+v", run()), gc.Matches, `(?s).*: cannot deploy\n\ngoroutine .*errors_test\.panicking.*`)
+}
+
+func (*panicSuite) TestRecoverReturnsError(c *gc.C) {
+	err := errors.Recover(func() error {
+		return io.EOF
+	})
+	c.Assert(err, gc.Equals, io.EOF)
+	c.Assert(errors.Recover(func() error { return nil }), gc.IsNil)
+}
+
+func (*panicSuite) TestRecoverErrorValue(c *gc.C) {
+	err := errors.Recover(func() error {
+		panicking(errors.NotFoundf("unit"))
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, "panic: unit not found")
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+}
+
+func (*panicSuite) TestDeferredRecover(c *gc.C) {
+	run := func() (err error) {
+		defer errors.DeferredRecover(&err)
+		var m map[string]int
+		m["x"] = 1
+		return nil
+	}
+	err := run()
+	c.Assert(err, gc.ErrorMatches, "panic: assignment to entry in nil map")
+	c.Assert(errors.Is(err, errors.Panic), gc.Equals, true)
+	// The location is where the panic occurred.
+	c.Assert(errors.Formatter{OmitPanicStacks: true}.String(err), gc.Matches,
+		`github.com/juju/errors_test\.\(\*panicSuite\)\.TestDeferredRecover\.func1:\d+: panic: assignment to entry in nil map`)
+
+	var runtimeErr runtime.Error
+	c.Assert(errors.As(err, &runtimeErr), gc.Equals, true)
+}
+
+func (*panicSuite) TestDeferredRecoverNoPanic(c *gc.C) {
+	run := func() (err error) {
+		defer errors.DeferredRecover(&err)
+		return io.EOF
+	}
+	c.Assert(run(), gc.Equals, io.EOF)
+}
+
+func (*panicSuite) TestRepanicRuntimeErrors(c *gc.C) {
+	errors.SetPolicy(errors.Policy{RepanicRuntimeErrors: true})
+	c.Assert(func() {
+		_ = errors.Recover(func() error {
+			var m map[string]int
+			m["x"] = 1
+			return nil
+		})
+	}, gc.PanicMatches, "assignment to entry in nil map")
+
+	// Other panics are still recovered.
+	err := errors.Recover(func() error {
+		panic("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "panic: boom")
+}
+
+func (*panicSuite) TestUnrecoverable(c *gc.C) {
+	c.Assert(func() {
+		_ = errors.Recover(func() error {
+			panic(http.ErrAbortHandler)
+		})
+	}, gc.PanicMatches, "net/http: abort Handler")
+
+	errUnrecoverable := errors.ConstError("unrecoverable")
+	errors.RegisterUnrecoverable(errUnrecoverable)
+	c.Assert(func() {
+		var err error
+		defer errors.DeferredRecover(&err)
+		panic(errors.Annotate(errUnrecoverable, "fatal"))
+	}, gc.PanicMatches, "fatal: unrecoverable")
+}
+
+func (*panicSuite) TestGoexit(c *gc.C) {
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		done <- errors.Recover(func() error {
+			runtime.Goexit()
+			return nil
+		})
+	}()
+	_, ok := <-done
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (*panicSuite) TestPanicOnGoexit(c *gc.C) {
+	errors.SetPolicy(errors.Policy{PanicOnGoexit: true})
+	done := make(chan interface{}, 1)
+	go func() {
+		defer close(done)
+		defer func() {
+			done <- recover()
+		}()
+		_ = errors.Recover(func() error {
+			runtime.Goexit()
+			return nil
+		})
+	}()
+	value := <-done
+	c.Assert(value, gc.FitsTypeOf, (*errors.PanicError)(nil))
+	c.Assert(value.(error), gc.ErrorMatches, "panic: runtime.Goexit called")
+}
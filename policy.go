@@ -0,0 +1,82 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// Policy controls optional behaviour of the errors created by this package.
+// The zero value is the default policy.
+type Policy struct {
+	// RecordTime causes errors to record the time at which they were
+	// created, traced or annotated. The times are shown by ErrorStack along
+	// with the time elapsed since the previous entry in the stack, which
+	// helps to diagnose slow failure paths.
+	RecordTime bool
+
+	// CollapseDuplicates collapses consecutive identical annotations in the
+	// output of Error, ErrorStack and Details into one with a count, such as
+	// "cannot deploy (x3): not found" rather than "cannot deploy: cannot
+	// deploy: cannot deploy: not found". It can also be enabled for a single
+	// error with the CollapseDuplicates function.
+	CollapseDuplicates bool
+
+	// MaxErrorLength, if positive, is the maximum length in bytes of the
+	// strings returned by ErrorStack, by Details and by the Error method of
+	// the errors created by this package, such as by New, Annotate,
+	// NotFoundf, NewNotValid, DecodeJSON and Group.Wait. Longer strings have
+	// their middle replaced with a marker stating the number of bytes
+	// elided, or with "…" if the limit is too small for it. The limit applies
+	// to the whole error string, not to each error in the chain. Limits
+	// below 11 bytes are treated as 11.
+	MaxErrorLength int
+
+	// MaxChainDepth, if positive, limits the number of consecutive layers
+	// added to an error chain by Trace, Annotate, Annotatef and
+	// DeferredAnnotatef, such as in a runaway retry loop. When a call would
+	// exceed the limit, the oldest of those layers are folded into a single
+	// layer stating how many layers were folded, so that the newest layers,
+	// the layers below them and the cause are kept. As the folded layer
+	// takes the place of all but the newest layer, a limit of 1 is treated
+	// as 2.
+	MaxChainDepth int
+
+	// RepanicRuntimeErrors causes Recover and DeferredRecover to panic
+	// again rather than recover when the panic value is a runtime.Error, such
+	// as a nil pointer dereference, so that programming errors crash the
+	// program.
+	RepanicRuntimeErrors bool
+
+	// PanicOnGoexit causes Recover to panic with a PanicError when the
+	// function it calls exits the goroutine with runtime.Goexit, which cannot
+	// be recovered, rather than let the goroutine exit silently.
+	PanicOnGoexit bool
+}
+
+var policy atomic.Pointer[Policy]
+
+// SetPolicy sets the policy used by this package and returns the previous
+// policy. Recording times and limiting the chain depth only affect errors
+// created or annotated after it is called, while the other options take effect
+// for all errors immediately.
+//
+// For example:
+//
+//	previous := errors.SetPolicy(errors.Policy{RecordTime: true})
+//	defer errors.SetPolicy(previous)
+func SetPolicy(p Policy) Policy {
+	if previous := policy.Swap(&p); previous != nil {
+		return *previous
+	}
+	return Policy{}
+}
+
+// CurrentPolicy returns the policy used by this package.
+func CurrentPolicy() Policy {
+	if p := policy.Load(); p != nil {
+		return *p
+	}
+	return Policy{}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type policySuite struct{}
+
+var _ = gc.Suite(&policySuite{})
+
+func (*policySuite) TestSetPolicy(c *gc.C) {
+	previous := errors.SetPolicy(errors.Policy{RecordTime: true})
+	c.Assert(errors.CurrentPolicy(), gc.Equals, errors.Policy{RecordTime: true})
+	c.Assert(errors.SetPolicy(previous), gc.Equals, errors.Policy{RecordTime: true})
+	c.Assert(errors.CurrentPolicy(), gc.Equals, previous)
+}
+
+func (*policySuite) TestTimesNotRecordedByDefault(c *gc.C) {
+	err := errors.Annotate(errors.NotFoundf("unit"), "cannot deploy")
+	c.Assert(err.(errors.Timestamper).Timestamp().IsZero(), gc.Equals, true)
+	for _, frame := range errors.Frames(err) {
+		c.Check(frame.Time.IsZero(), gc.Equals, true)
+	}
+	c.Assert(errors.ErrorStack(err), gc.Not(Contains), "[")
+}
+
+func (*policySuite) TestRecordTime(c *gc.C) {
+	previous := errors.SetPolicy(errors.Policy{RecordTime: true})
+	defer errors.SetPolicy(previous)
+
+	before := time.Now()
+	err := errors.NotFoundf("unit")
+	err = errors.Trace(err)
+	err = errors.Annotate(err, "cannot deploy")
+	after := time.Now()
+
+	stamp := err.(errors.Timestamper).Timestamp()
+	c.Assert(stamp.Before(before), gc.Equals, false)
+	c.Assert(stamp.After(after), gc.Equals, false)
+
+	frames := errors.Frames(err)
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(frames[0].Elapsed, gc.Equals, time.Duration(0))
+	for i := 1; i < len(frames); i++ {
+		c.Check(frames[i].Elapsed, gc.Equals, frames[i].Time.Sub(frames[i-1].Time))
+	}
+	c.Check(errors.ErrorStack(err), gc.Matches,
+		`\[\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z \+0s\] .*: unit not found\n`+
+			`\[.* \+.*\] .*: \n`+
+			`\[.* \+.*\] .*: cannot deploy`)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// This file holds a minimal encoder and decoder for the protocol buffers wire
+// format, sufficient for the messages used by EncodeStatus and DecodeStatus,
+// so that this package does not depend on a protobuf implementation.
+
+// Wire types of the protocol buffers encoding.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// protoEncoder appends the fields of a protobuf message to a buffer.
+type protoEncoder struct {
+	buf []byte
+}
+
+func (e *protoEncoder) tag(field, wireType int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|uint64(wireType))
+}
+
+// varint appends an integer field, omitting it if it has the default value.
+func (e *protoEncoder) varint(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, protoVarint)
+	e.buf = binary.AppendUvarint(e.buf, uint64(v))
+}
+
+// bytes appends a length-delimited field, omitting it if it is empty.
+func (e *protoEncoder) bytes(field int, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+	e.tag(field, protoBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// string appends a string field, omitting it if it is empty.
+func (e *protoEncoder) string(field int, v string) {
+	e.bytes(field, []byte(v))
+}
+
+// message appends an embedded message field, which is present even when
+// the message is empty.
+func (e *protoEncoder) message(field int, m protoEncoder) {
+	e.tag(field, protoBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(m.buf)))
+	e.buf = append(e.buf, m.buf...)
+}
+
+// protoField is a field read from a protobuf message. Only one of varint and
+// bytes is set, depending on the wire type of the field.
+type protoField struct {
+	number   int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+// decodeProto calls fn for each field of the protobuf message in data. Fields
+// of the fixed-size wire types are skipped.
+func decodeProto(data []byte, fn func(protoField) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return NotValidf("protobuf field key")
+		}
+		data = data[n:]
+		f := protoField{number: int(key >> 3), wireType: int(key & 7)}
+		switch f.wireType {
+		case protoVarint:
+			if f.varint, n = binary.Uvarint(data); n <= 0 {
+				return NotValidf("protobuf varint in field %d", f.number)
+			}
+			data = data[n:]
+		case protoBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return NotValidf("protobuf length in field %d", f.number)
+			}
+			f.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case protoFixed64, protoFixed32:
+			size := 8
+			if f.wireType == protoFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return NotValidf("protobuf fixed-size value in field %d", f.number)
+			}
+			data = data[size:]
+			continue
+		default:
+			return NotValidf("protobuf wire type %d in field %d", f.wireType, f.number)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// redacted is the text shown in place of a secret value.
+const redacted = "<redacted>"
+
+// Secret marks v as a sensitive value, such as a password, token or user
+// data, so that it is redacted when formatted into an error message.
+//
+// For example:
+//
+//	return errors.Annotatef(err, "login %s failed", errors.Secret(user))
+//
+// renders as "login <redacted> failed: ..." from Error, ErrorStack and %+v.
+// The original value is only shown through the view returned by Unredacted.
+func Secret(v interface{}) interface{} {
+	return secret{value: v}
+}
+
+// secret holds a value marked by Secret.
+type secret struct {
+	value interface{}
+}
+
+// Format implements fmt.Formatter so that the value is redacted whatever
+// verb is used to format it.
+func (secret) Format(s fmt.State, _ rune) {
+	fmt.Fprint(s, redacted)
+}
+
+// String implements fmt.Stringer.
+func (secret) String() string {
+	return redacted
+}
+
+// MarshalText implements encoding.TextMarshaler so that secrets attached to
+// errors as fields are also redacted when encoded.
+func (secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue implements slog.LogValuer so that secrets attached to errors as
+// fields are also redacted in logs.
+func (secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// hasSecrets reports whether any of args has been marked by Secret.
+func hasSecrets(args []interface{}) bool {
+	for _, arg := range args {
+		if _, ok := arg.(secret); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// retainedFormat returns the format and arguments of a message to keep in an
+// error, so that the message can be rendered again without redacting secrets
+// or in another language. Secrets and arguments of basic types, such as
+// strings and numbers, are kept as they are. Other arguments are kept only as
+// the text they were formatted to, so that errors do not keep the objects
+// passed as arguments alive; a translation cannot format them differently and
+// includes that text whatever verb it uses.
+func retainedFormat(format string, args []interface{}) (string, []interface{}) {
+	var (
+		retained   []interface{}
+		directives []string
+	)
+	for i, arg := range args {
+		if _, ok := arg.(secret); ok || isBasic(arg) {
+			continue
+		}
+		if retained == nil {
+			retained = append([]interface{}(nil), args...)
+			directives = argDirectives(format, len(args))
+		}
+		retained[i] = renderedArg(fmt.Sprintf(directives[i], arg))
+	}
+	if retained == nil {
+		return format, args
+	}
+	return format, retained
+}
+
+// isBasic reports whether v is nil or a value of a basic type.
+func isBasic(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Invalid, reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// renderedArg is an argument of a message kept as the text it was formatted
+// to by retainedFormat.
+type renderedArg string
+
+// Format implements fmt.Formatter, writing the text whatever the verb.
+func (a renderedArg) Format(s fmt.State, _ rune) {
+	io.WriteString(s, string(a))
+}
+
+// argDirectives returns the directive, such as "%-8q", with which format
+// formats each of n arguments, without any explicit argument index or width
+// or precision taken from the arguments. Arguments that format does not use
+// have the directive "%v".
+func argDirectives(format string, n int) []string {
+	directives := make([]string, n)
+	for i := range directives {
+		directives[i] = "%v"
+	}
+	argNum := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		directive := []byte{'%'}
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*[", format[i]) >= 0; i++ {
+			switch format[i] {
+			case '*':
+				argNum++
+			case '[':
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					return directives
+				}
+				if index, err := strconv.Atoi(format[i+1 : i+end]); err == nil {
+					argNum = index - 1
+				}
+				i += end
+			default:
+				directive = append(directive, format[i])
+			}
+		}
+		if i == len(format) {
+			break
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		if verb == '%' {
+			continue
+		}
+		if argNum >= 0 && argNum < n {
+			directives[argNum] = string(directive) + string(verb)
+		}
+		argNum++
+	}
+	return directives
+}
+
+// reveal returns a copy of args with the original values of any secrets.
+func reveal(args []interface{}) []interface{} {
+	revealed := make([]interface{}, len(args))
+	for i, arg := range args {
+		if s, ok := arg.(secret); ok {
+			arg = s.value
+		}
+		revealed[i] = arg
+	}
+	return revealed
+}
+
+// Unredacted returns a privileged view of err in which values marked by
+// Secret are shown. Its Error method returns the error string of err with the
+// original values, and formatting it with %+v writes the error stack of err
+// with the original values, as ErrorStack would. The view unwraps to err so
+// Is and As continue to work. If err is nil, the result will be nil.
+//
+// Only errors created by this package can reveal their secrets; an error from
+// another package that wraps them renders its redacted text.
+func Unredacted(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &unredactedError{err: err}
+}
+
+// unredactedError is the view returned by Unredacted.
+type unredactedError struct {
+	err error
+}
+
+// Error implements error.
+func (u *unredactedError) Error() string {
+	return u.renderText(textOptions{})
+}
+
+// renderText implements textRenderer.
+func (u *unredactedError) renderText(o textOptions) string {
+	o.reveal = true
+	return errorString(u.err, o)
+}
+
+// Unwrap returns the error being viewed.
+func (u *unredactedError) Unwrap() error {
+	return u.err
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack with secrets revealed.
+func (u *unredactedError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_ = defaultFormatter.format(s, u.err, textOptions{reveal: true})
+			return
+		}
+		fallthrough
+	case 's':
+		fmt.Fprint(s, u.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", u.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, u, u.Error())
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type redactSuite struct{}
+
+var _ = gc.Suite(&redactSuite{})
+
+func (*redactSuite) TestSecretIsRedacted(c *gc.C) {
+	err := errors.Errorf("token %q rejected", errors.Secret("s3cr3t"))
+	err = errors.Annotatef(err, "login %s failed", errors.Secret("bob"))
+
+	c.Assert(err.Error(), gc.Equals, "login <redacted> failed: token <redacted> rejected")
+	c.Assert(errors.ErrorStack(err), gc.Not(Contains), "s3cr3t")
+	c.Assert(fmt.Sprintf("%+v", err), gc.Not(Contains), "bob")
+	c.Assert(fmt.Sprint(errors.Secret("bob")), gc.Equals, "<redacted>")
+}
+
+func (*redactSuite) TestUnredacted(c *gc.C) {
+	err := errors.Errorf("token %q rejected", errors.Secret("s3cr3t"))
+	err = errors.Trace(err)
+	err = errors.Annotatef(err, "login %s failed", errors.Secret("bob"))
+
+	view := errors.Unredacted(err)
+	c.Assert(view.Error(), gc.Equals, `login bob failed: token "s3cr3t" rejected`)
+	c.Assert(errors.Is(view, err), gc.Equals, true)
+
+	stack := fmt.Sprintf("%+v", view)
+	lines := strings.Split(stack, "\n")
+	c.Assert(lines, gc.HasLen, 3)
+	c.Check(lines[0], gc.Matches, `.*: token "s3cr3t" rejected`)
+	c.Check(lines[2], gc.Matches, `.*: login bob failed`)
+	c.Check(errors.ErrorStack(err), gc.Equals, strings.Replace(
+		strings.Replace(stack, `"s3cr3t"`, "<redacted>", 1), "bob", "<redacted>", 1))
+
+	c.Assert(errors.Unredacted(nil), gc.IsNil)
+}
+
+func (*redactSuite) TestUnredactedTypedErrors(c *gc.C) {
+	err := errors.NotFoundf("user %s", errors.Secret("bob"))
+	c.Assert(err.Error(), gc.Equals, "user <redacted> not found")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, "user bob not found")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+
+	err = errors.NewUnauthorized(err, "cannot log in")
+	c.Assert(err.Error(), gc.Equals, "cannot log in: user <redacted> not found")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, "cannot log in: user bob not found")
+
+	err = errors.Wrapf(err, errors.New("denied"), "password %s", errors.Secret("hunter2"))
+	c.Assert(err.Error(), gc.Equals, "password <redacted>: denied")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, "password hunter2: denied")
+}
+
+func (*redactSuite) TestUnredactedWithoutSecrets(c *gc.C) {
+	err := errors.Annotatef(errors.NotFoundf("user %s", "bob"), "cannot log in")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, err.Error())
+	c.Assert(fmt.Sprintf("%+v", errors.Unredacted(err)), gc.Equals, errors.ErrorStack(err))
+}
+
+type bulkyValue struct {
+	data []byte
+}
+
+func (b *bulkyValue) String() string {
+	return fmt.Sprintf("%d bytes", len(b.data))
+}
+
+func (*redactSuite) TestArgumentsNotRetained(c *gc.C) {
+	bulky := &bulkyValue{data: make([]byte, 1024)}
+	err := errors.Annotatef(errors.New("boom"), "cannot store %v", bulky)
+	c.Assert(err, gc.ErrorMatches, "cannot store 1024 bytes: boom")
+	c.Assert(fmt.Sprintf("%#v", err), gc.Not(Contains), "bulkyValue")
+
+	// Only secrets are kept as they are, to reveal them.
+	err = errors.Annotatef(errors.New("boom"), "cannot store %-12v|%q for %s", bulky, bulky, errors.Secret("admin"))
+	c.Assert(fmt.Sprintf("%#v", err), gc.Not(Contains), "bulkyValue")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, `cannot store 1024 bytes  |"1024 bytes" for admin: boom`)
+
+	err = errors.NotFoundf("cannot store %v for %s", bulky, errors.Secret("admin"))
+	c.Assert(fmt.Sprintf("%#v", err), gc.Not(Contains), "bulkyValue")
+	c.Assert(errors.Unredacted(err).Error(), gc.Equals, "cannot store 1024 bytes for admin not found")
+}
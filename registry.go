@@ -0,0 +1,193 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DecodedParts holds the parts of a decoded error that are managed by this
+// package, for use by the decode function registered with RegisterErrorType.
+type DecodedParts struct {
+	// Err is the decoded Err embedded in the original error, if its type
+	// embeds Err. Otherwise it is the zero Err.
+	Err Err
+
+	// Wrapped is the decoded error wrapped by the original error, if its type
+	// does not embed Err and it implements Unwrap.
+	Wrapped error
+}
+
+// registeredType holds the hooks for an error type registered with
+// RegisterErrorType.
+type registeredType struct {
+	name   string
+	encode func(error) ([]byte, error)
+	decode func([]byte, DecodedParts) (error, error)
+}
+
+var (
+	registryMutex  sync.RWMutex
+	registryByName = make(map[string]*registeredType)
+	registryByType = make(map[reflect.Type]*registeredType)
+)
+
+// errorCore is implemented by *Err and by the types that embed it.
+type errorCore interface {
+	core() *Err
+}
+
+// core implements errorCore. As it is promoted to the types that embed Err,
+// it gives this package access to the Err of custom error types.
+func (e *Err) core() *Err {
+	return e
+}
+
+// RegisterErrorType registers the custom error type T under name so that
+// errors of that type survive the wire encodings of this package, such as
+// EncodeJSON. Both the encoding and decoding sides must register the type
+// under the same name. On the decoding side, the error can be recovered with
+// AsType[T]. Errors of a type that is not registered by the decoding side are
+// decoded as an *UnregisteredError.
+//
+// The encode function returns the data specific to T. The decode function
+// rebuilds the error from that data and the parts managed by this package:
+// if T embeds Err, as with the FooError pattern documented with NewErr, the
+// decoded Err holding its messages, locations and previous errors is passed
+// in parts.Err; otherwise any error wrapped by T is passed in parts.Wrapped.
+//
+// For example:
+//
+//	errors.RegisterErrorType("foo", func(e *FooError) ([]byte, error) {
+//	    return json.Marshal(e.code)
+//	}, func(data []byte, parts errors.DecodedParts) (*FooError, error) {
+//	    err := &FooError{Err: parts.Err}
+//	    return err, json.Unmarshal(data, &err.code)
+//	})
+//
+// An error satisfying AlreadyExists is returned if either name or T has
+// already been registered.
+func RegisterErrorType[T error](
+	name string,
+	encode func(T) ([]byte, error),
+	decode func(data []byte, parts DecodedParts) (T, error),
+) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := registryByName[name]; ok {
+		return AlreadyExistsf("error type name %q", name)
+	}
+	if _, ok := registryByType[t]; ok {
+		return AlreadyExistsf("error type %s", t)
+	}
+	rt := &registeredType{
+		name: name,
+		encode: func(err error) ([]byte, error) {
+			return encode(err.(T))
+		},
+		decode: func(data []byte, parts DecodedParts) (error, error) {
+			return decode(data, parts)
+		},
+	}
+	registryByName[name] = rt
+	registryByType[t] = rt
+	return nil
+}
+
+// registeredTypeOf returns the registration for the type of err, if any.
+func registeredTypeOf(err error) (*registeredType, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	rt, ok := registryByType[reflect.TypeOf(err)]
+	return rt, ok
+}
+
+// registeredTypeNamed returns the registration with the given name, if any.
+func registeredTypeNamed(name string) (*registeredType, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	rt, ok := registryByName[name]
+	return rt, ok
+}
+
+// UnregisteredError is the placeholder for a decoded error whose type was
+// registered with RegisterErrorType by the encoding side but not by the
+// decoding side. It has the same error string and error stack as the
+// original error, and keeps the type-specific data so that it can be
+// inspected or encoded again.
+type UnregisteredError struct {
+	// TypeName is the name the type of the original error was registered
+	// under.
+	TypeName string
+
+	// Data is the data returned by the encode function registered for the
+	// type.
+	Data []byte
+
+	message string
+	base    *Err
+	wrapped error
+}
+
+var (
+	_ wrapper    = (*UnregisteredError)(nil)
+	_ Locationer = (*UnregisteredError)(nil)
+	_ causer     = (*UnregisteredError)(nil)
+)
+
+// Error implements error, returning the error string of the original error.
+func (u *UnregisteredError) Error() string {
+	return u.message
+}
+
+// Message implements wrapper, returning the message of the Err embedded in
+// the original error, or the error string of the original error if it did
+// not embed one.
+func (u *UnregisteredError) Message() string {
+	if u.base == nil {
+		return u.message
+	}
+	return u.base.Message()
+}
+
+// Underlying implements wrapper.
+func (u *UnregisteredError) Underlying() error {
+	if u.base == nil {
+		return nil
+	}
+	return u.base.Underlying()
+}
+
+// Cause implements causer.
+func (u *UnregisteredError) Cause() error {
+	if u.base == nil {
+		return nil
+	}
+	return u.base.Cause()
+}
+
+// Location implements Locationer.
+func (u *UnregisteredError) Location() (string, int) {
+	if u.base == nil {
+		return "", 0
+	}
+	return u.base.Location()
+}
+
+// Unwrap returns the error wrapped by the original error.
+func (u *UnregisteredError) Unwrap() error {
+	if u.base == nil {
+		return u.wrapped
+	}
+	return u.base.Unwrap()
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (u *UnregisteredError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, u)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type registrySuite struct{}
+
+var _ = gc.Suite(&registrySuite{})
+
+// quotaError is a custom error type embedding Err.
+type quotaError struct {
+	errors.Err
+	limit int
+}
+
+func newQuotaError(limit int) error {
+	err := &quotaError{Err: errors.NewErr("quota of %d exceeded", limit), limit: limit}
+	err.SetLocation(1)
+	return err
+}
+
+// retryError is a custom error type wrapping another error.
+type retryError struct {
+	attempts int
+	err      error
+}
+
+func (e *retryError) Error() string {
+	return "after " + strconv.Itoa(e.attempts) + " attempts: " + e.err.Error()
+}
+
+func (e *retryError) Unwrap() error {
+	return e.err
+}
+
+func init() {
+	err := errors.RegisterErrorType("registry_test.quota", func(e *quotaError) ([]byte, error) {
+		return json.Marshal(e.limit)
+	}, func(data []byte, parts errors.DecodedParts) (*quotaError, error) {
+		err := &quotaError{Err: parts.Err}
+		return err, json.Unmarshal(data, &err.limit)
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = errors.RegisterErrorType("registry_test.retry", func(e *retryError) ([]byte, error) {
+		return json.Marshal(e.attempts)
+	}, func(data []byte, parts errors.DecodedParts) (*retryError, error) {
+		err := &retryError{err: parts.Wrapped}
+		return err, json.Unmarshal(data, &err.attempts)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (*registrySuite) TestRoundTripEmbeddingErr(c *gc.C) {
+	err := errors.Annotate(newQuotaError(10), "cannot deploy")
+	decoded := roundTripJSON(c, err)
+
+	quota, ok := errors.AsType[*quotaError](decoded)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(quota.limit, gc.Equals, 10)
+	c.Assert(quota.Error(), gc.Equals, "quota of 10 exceeded")
+	original, _ := errors.AsType[*quotaError](err)
+	c.Assert(errors.ErrorStack(quota), gc.Equals, errors.ErrorStack(original))
+}
+
+func (*registrySuite) TestRoundTripWrapping(c *gc.C) {
+	err := errors.Trace(&retryError{attempts: 3, err: errors.NotFoundf("unit")})
+	decoded := roundTripJSON(c, err)
+
+	retry, ok := errors.AsType[*retryError](decoded)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(retry.attempts, gc.Equals, 3)
+	c.Assert(errors.Is(retry, errors.NotFound), gc.Equals, true)
+}
+
+func (*registrySuite) TestUnregistered(c *gc.C) {
+	err := errors.Annotate(newQuotaError(10), "cannot deploy")
+	data, encodeErr := errors.EncodeJSON(err)
+	c.Assert(encodeErr, gc.IsNil)
+
+	// Simulate a decoding side that has not registered the type.
+	data = []byte(strings.ReplaceAll(string(data), "registry_test.quota", "registry_test.unknown"))
+	decoded, decodeErr := errors.DecodeJSON(data)
+	c.Assert(decodeErr, gc.IsNil)
+	c.Assert(decoded.Error(), gc.Equals, err.Error())
+	c.Assert(errors.ErrorStack(decoded), gc.Equals, remoteStack(err))
+
+	unregistered, ok := errors.AsType[*errors.UnregisteredError](decoded)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(unregistered.TypeName, gc.Equals, "registry_test.unknown")
+	c.Assert(string(unregistered.Data), gc.Equals, "10")
+
+	// The placeholder encodes again without losing the data.
+	data, encodeErr = errors.EncodeJSON(decoded)
+	c.Assert(encodeErr, gc.IsNil)
+	c.Assert(string(data), Contains, `"name":"registry_test.unknown","data":"MTA="`)
+}
+
+func (*registrySuite) TestRegisterDuplicate(c *gc.C) {
+	err := errors.RegisterErrorType("registry_test.quota", func(e *retryError) ([]byte, error) {
+		return nil, nil
+	}, func([]byte, errors.DecodedParts) (*retryError, error) {
+		return nil, nil
+	})
+	c.Assert(err, gc.ErrorMatches, `error type name "registry_test.quota" already exists`)
+	c.Assert(errors.Is(err, errors.AlreadyExists), gc.Equals, true)
+
+	err = errors.RegisterErrorType("registry_test.other", func(e *retryError) ([]byte, error) {
+		return nil, nil
+	}, func([]byte, errors.DecodedParts) (*retryError, error) {
+		return nil, nil
+	})
+	c.Assert(err, gc.ErrorMatches, `error type \*errors_test.retryError already exists`)
+}
+
+func (*registrySuite) TestEncodeFailure(c *gc.C) {
+	err := errors.RegisterErrorType("registry_test.failing", func(e *failingError) ([]byte, error) {
+		return nil, errors.New("boom")
+	}, func([]byte, errors.DecodedParts) (*failingError, error) {
+		return nil, nil
+	})
+	c.Assert(err, gc.IsNil)
+	defer errors.UnregisterErrorType("registry_test.failing")
+	_, err = errors.EncodeJSON(errors.Trace(&failingError{}))
+	c.Assert(err, gc.ErrorMatches, `encoding error type "registry_test.failing": boom`)
+}
+
+type failingError struct{}
+
+func (*failingError) Error() string { return "failing" }
@@ -0,0 +1,110 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Origin identifies the process an error was created in.
+type Origin struct {
+	// Service is the name of the service running in the process.
+	Service string
+
+	// Host is the name of the host running the process.
+	Host string
+}
+
+// String returns the origin in the form service@host, omitting the parts
+// that are not known.
+func (o Origin) String() string {
+	switch {
+	case o.Service == "":
+		return o.Host
+	case o.Host == "":
+		return o.Service
+	}
+	return o.Service + "@" + o.Host
+}
+
+var localOrigin atomic.Pointer[Origin]
+
+// SetLocalOrigin sets the origin recorded by the encoders of this package,
+// such as EncodeJSON, which is the origin of the RemoteError returned by the
+// decoders on the receiving side. By default, the origin is empty and is not
+// recorded, so that the names of the program and of its host are only
+// disclosed to the receivers of errors when explicitly set.
+func SetLocalOrigin(origin Origin) {
+	localOrigin.Store(&origin)
+}
+
+// LocalOrigin returns the origin set with SetLocalOrigin, or the empty origin
+// if none was set.
+func LocalOrigin() Origin {
+	if origin := localOrigin.Load(); origin != nil {
+		return *origin
+	}
+	return Origin{}
+}
+
+// RemoteError is an error received from another process, as returned by the
+// decoders of this package such as DecodeJSON. It marks the boundary between
+// the remote error stack and the local annotations added after the error was
+// received: the entries of its remote stack are shown by ErrorStack and
+// Details with a "remote: " prefix. Its error string is that of the remote
+// error, and it satisfies Is for the same error types.
+type RemoteError struct {
+	// Origin is the origin of the remote error, if it is known.
+	Origin Origin
+
+	err error
+}
+
+var _ causer = (*RemoteError)(nil)
+
+// NewRemoteError returns an error marking err as received from the process
+// identified by origin. This is useful for errors received by means other than
+// the decoders of this package. If err is nil, the result will be nil.
+func NewRemoteError(err error, origin Origin) error {
+	if err == nil {
+		return nil
+	}
+	return &RemoteError{Origin: origin, err: err}
+}
+
+// Error implements error, returning the error string of the remote error.
+func (r *RemoteError) Error() string {
+	return limitLength(r.renderText(textOptions{}))
+}
+
+// renderText implements textRenderer.
+func (r *RemoteError) renderText(o textOptions) string {
+	return errorString(r.err, o)
+}
+
+// frames returns the entries of the remote stack, marked as remote.
+func (r *RemoteError) frames(o textOptions) []Frame {
+	frames := frames(r.err, o)
+	for i := range frames {
+		frames[i].Remote = true
+	}
+	return frames
+}
+
+// Cause implements causer, returning the cause of the remote error.
+func (r *RemoteError) Cause() error {
+	return Cause(r.err)
+}
+
+// Unwrap returns the remote error.
+func (r *RemoteError) Unwrap() error {
+	return r.err
+}
+
+// Format implements fmt.Formatter. When printing with %+v it prints the
+// error stack.
+func (r *RemoteError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, r)
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type remoteSuite struct {
+	origin errors.Origin
+}
+
+var _ = gc.Suite(&remoteSuite{})
+
+func (s *remoteSuite) SetUpTest(c *gc.C) {
+	s.origin = errors.LocalOrigin()
+	errors.SetLocalOrigin(errors.Origin{Service: "jujud", Host: "controller-0"})
+}
+
+func (s *remoteSuite) TearDownTest(c *gc.C) {
+	errors.SetLocalOrigin(s.origin)
+}
+
+func (s *remoteSuite) TestNoOriginByDefault(c *gc.C) {
+	errors.SetLocalOrigin(s.origin)
+	c.Assert(errors.LocalOrigin(), gc.Equals, errors.Origin{})
+
+	data, err := errors.EncodeJSON(errors.New("boom"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(string(data), gc.Not(Contains), `"origin"`)
+	decoded, err := errors.DecodeJSON(data)
+	c.Assert(err, gc.IsNil)
+	c.Assert(decoded.(*errors.RemoteError).Origin, gc.Equals, errors.Origin{})
+
+	data, err = errors.EncodeStatus(errors.New("boom"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(string(data), gc.Not(Contains), `"origin"`)
+}
+
+func (*remoteSuite) TestDecodedError(c *gc.C) {
+	remote := errors.Annotate(errors.NotFoundf("unit %q", "mysql/0"), "cannot deploy")
+	data, err := errors.EncodeJSON(remote)
+	c.Assert(err, gc.IsNil)
+	decoded, err := errors.DecodeJSON(data)
+	c.Assert(err, gc.IsNil)
+
+	local := errors.Trace(decoded)
+	loc := errorLocationValue(c)
+	local = errors.Annotate(local, "agent failed")
+
+	c.Assert(local.Error(), gc.Equals, `agent failed: cannot deploy: unit "mysql/0" not found`)
+	c.Assert(errors.Is(local, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.Cause(local), gc.Equals, errors.Cause(decoded))
+
+	remoteErr, ok := errors.AsType[*errors.RemoteError](local)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(remoteErr.Origin, gc.Equals, errors.Origin{Service: "jujud", Host: "controller-0"})
+	c.Assert(remoteErr.Origin.String(), gc.Equals, "jujud@controller-0")
+
+	lines := strings.Split(errors.ErrorStack(local), "\n")
+	c.Assert(lines, gc.HasLen, 4)
+	c.Assert(lines[0], gc.Matches, `remote: .*TestDecodedError:\d+: unit "mysql/0" not found`)
+	c.Assert(lines[1], gc.Matches, `remote: .*TestDecodedError:\d+: cannot deploy`)
+	c.Assert(lines[2], gc.Equals, loc+": ")
+	c.Assert(lines[3], gc.Matches, `.*TestDecodedError:\d+: agent failed`)
+
+	c.Assert(errors.Details(local), gc.Matches,
+		`\[\{.*: agent failed\} \{`+regexp.QuoteMeta(loc)+`: \} \{remote: .*: cannot deploy\} \{remote: .*: unit "mysql/0" not found\}\]`)
+
+	frames := errors.Frames(local)
+	c.Assert(frames, gc.HasLen, 4)
+	c.Assert(frames[1].Remote, gc.Equals, true)
+	c.Assert(frames[2].Remote, gc.Equals, false)
+}
+
+func (*remoteSuite) TestForwardedError(c *gc.C) {
+	data, err := errors.EncodeJSON(errors.New("boom"))
+	c.Assert(err, gc.IsNil)
+	decoded, err := errors.DecodeJSON(data)
+	c.Assert(err, gc.IsNil)
+
+	errors.SetLocalOrigin(errors.Origin{Service: "jujud", Host: "machine-1"})
+	data, err = errors.EncodeJSON(errors.Annotate(decoded, "forwarded"))
+	c.Assert(err, gc.IsNil)
+	forwarded, err := errors.DecodeJSON(data)
+	c.Assert(err, gc.IsNil)
+
+	c.Assert(forwarded.(*errors.RemoteError).Origin.Host, gc.Equals, "machine-1")
+	inner, ok := errors.AsType[*errors.RemoteError](errors.Unwrap(forwarded))
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(inner.Origin.Host, gc.Equals, "controller-0")
+	c.Assert(forwarded.Error(), gc.Equals, "forwarded: boom")
+	c.Assert(errors.ErrorStack(forwarded), gc.Matches, `remote: .*: boom\nremote: .*: forwarded`)
+}
+
+func (*remoteSuite) TestNewRemoteError(c *gc.C) {
+	c.Assert(errors.NewRemoteError(nil, errors.Origin{}), gc.IsNil)
+
+	err := errors.NewRemoteError(fmt.Errorf("boom"), errors.Origin{Host: "db-0"})
+	c.Assert(err.Error(), gc.Equals, "boom")
+	c.Assert(errors.ErrorStack(err), gc.Equals, "remote: boom")
+	c.Assert(errors.Details(err), gc.Equals, "[{remote: boom}]")
+	c.Assert(err.(*errors.RemoteError).Origin.String(), gc.Equals, "db-0")
+}
+
+func (*remoteSuite) TestForeignStatus(c *gc.C) {
+	decoded, err := errors.DecodeStatus([]byte("\x08\x05" + protoString(0x12, "unit not found")))
+	c.Assert(err, gc.IsNil)
+	remote, ok := decoded.(*errors.RemoteError)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(remote.Origin, gc.Equals, errors.Origin{})
+	c.Assert(errors.Is(decoded, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.ErrorStack(decoded), gc.Equals, "remote: unit not found")
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// report holds the information about an error shown by RenderMarkdown and
+// RenderHTML.
+type report struct {
+	message string
+	kinds   []string
+	trace   string
+	hints   []Hint
+	fields  []Field
+	frames  []Frame
+	times   bool
+}
+
+func newReport(err error) report {
+	r := report{
+		message: err.Error(),
+		hints:   Hints(err),
+		fields:  Fields(err),
+		frames:  Frames(err),
+	}
+	for _, kind := range errorKinds(err) {
+		r.kinds = append(r.kinds, string(kind))
+	}
+	if tc, ok := attached[traceContext](err); ok {
+		r.trace = tc.String()
+	}
+	for _, frame := range r.frames {
+		r.times = r.times || !frame.Time.IsZero()
+	}
+	return r
+}
+
+// frameTime returns the time of frame as shown by ErrorStack, or the empty
+// string if it was not recorded.
+func frameTime(frame Frame) string {
+	if frame.Time.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s +%s", frame.Time.UTC().Format(frameTimeFormat), frame.Elapsed)
+}
+
+// frameLocation returns the location of frame as shown by ErrorStack, or the
+// empty string if it has none.
+func frameLocation(frame Frame) string {
+	if frame.Function == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+}
+
+// RenderMarkdown renders a report about err in GitHub flavoured Markdown,
+// suitable for pasting into bug reports. The report is collapsible: its
+// summary is the error string of err, and its body lists the kinds of err,
+// its trace context, hints and fields, followed by a table of the entries of
+// its annotation stack, as rendered by ErrorStack. The result is empty if err
+// is nil.
+func RenderMarkdown(err error) string {
+	if err == nil {
+		return ""
+	}
+	r := newReport(err)
+	var b strings.Builder
+	b.WriteString("<details>\n<summary>" + html.EscapeString(r.message) + "</summary>\n\n")
+	if len(r.kinds) > 0 {
+		b.WriteString("- **Kinds:** " + markdownEscape(strings.Join(r.kinds, ", ")) + "\n")
+	}
+	if r.trace != "" {
+		b.WriteString("- **Trace:** " + markdownCode(r.trace) + "\n")
+	}
+	if len(r.hints) > 0 {
+		b.WriteString("- **Hints:**\n")
+		for _, h := range r.hints {
+			b.WriteString("  - " + markdownHint(h) + "\n")
+		}
+	}
+	if len(r.fields) > 0 {
+		b.WriteString("- **Fields:**\n")
+		for _, f := range r.fields {
+			b.WriteString("  - " + markdownCode(f.Key) + ": " + markdownEscape(fmt.Sprint(f.Value)) + "\n")
+		}
+	}
+	if len(r.kinds) > 0 || r.trace != "" || len(r.hints) > 0 || len(r.fields) > 0 {
+		b.WriteString("\n")
+	}
+
+	columns := []string{"#", "Location", "Message", "Cause"}
+	if r.times {
+		columns = []string{"#", "Time", "Location", "Message", "Cause"}
+	}
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString(strings.Repeat("| --- ", len(columns)) + "|\n")
+	for i, frame := range r.frames {
+		cells := []string{strconv.Itoa(i + 1)}
+		if r.times {
+			cells = append(cells, markdownEscape(frameTime(frame)))
+		}
+		location := markdownCode(frameLocation(frame))
+		if frame.Remote {
+			location = strings.TrimSpace("remote: " + location)
+		}
+		cells = append(cells, location, markdownEscape(frame.Message), markdownEscape(frame.Cause))
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	b.WriteString("\n</details>\n")
+	return b.String()
+}
+
+// markdownEscape escapes s so that it renders as plain text in Markdown,
+// including in a table cell.
+func markdownEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '|', '~', '#':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '&':
+			b.WriteString("&amp;")
+		case '\n':
+			b.WriteString("<br>")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// markdownCode renders s as inline code, or returns the empty string if s is
+// empty.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "|", `\|`)
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	return fence + " " + s + " " + fence
+}
+
+// markdownHint renders h as Markdown, with a link to its URL if it is safe.
+func markdownHint(h Hint) string {
+	text := markdownEscape(h.Text)
+	if !safeURL(h.URL) {
+		if h.URL == "" {
+			return text
+		}
+		return strings.TrimSpace(text + " " + markdownEscape(h.URL))
+	}
+	link := "<" + strings.NewReplacer("<", "%3C", ">", "%3E", " ", "%20").Replace(h.URL) + ">"
+	return strings.TrimSpace(text + " " + link)
+}
+
+// safeURL reports whether rawURL is an absolute http or https URL, which is
+// safe to render as a link.
+func safeURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// RenderHTML renders a report about err as an HTML fragment, suitable for
+// showing on a web page. The report has the same content as the one rendered
+// by RenderMarkdown, in a collapsible details element of the class
+// "error-report", and all text from err is escaped. Only http and https hint
+// URLs are rendered as links. The result is empty if err is nil.
+func RenderHTML(err error) string {
+	if err == nil {
+		return ""
+	}
+	r := newReport(err)
+	esc := html.EscapeString
+	var b strings.Builder
+	b.WriteString(`<details class="error-report">` + "\n")
+	b.WriteString("<summary>" + esc(r.message) + "</summary>\n")
+	if len(r.kinds) > 0 || r.trace != "" || len(r.hints) > 0 || len(r.fields) > 0 {
+		b.WriteString("<dl>\n")
+		if len(r.kinds) > 0 {
+			b.WriteString("<dt>Kinds</dt><dd>" + esc(strings.Join(r.kinds, ", ")) + "</dd>\n")
+		}
+		if r.trace != "" {
+			b.WriteString("<dt>Trace</dt><dd><code>" + esc(r.trace) + "</code></dd>\n")
+		}
+		if len(r.hints) > 0 {
+			b.WriteString("<dt>Hints</dt><dd><ul>")
+			for _, h := range r.hints {
+				b.WriteString("<li>" + htmlHint(h) + "</li>")
+			}
+			b.WriteString("</ul></dd>\n")
+		}
+		if len(r.fields) > 0 {
+			b.WriteString("<dt>Fields</dt><dd><ul>")
+			for _, f := range r.fields {
+				b.WriteString("<li><code>" + esc(f.Key) + "</code>: " + esc(fmt.Sprint(f.Value)) + "</li>")
+			}
+			b.WriteString("</ul></dd>\n")
+		}
+		b.WriteString("</dl>\n")
+	}
+
+	b.WriteString("<table>\n<thead><tr><th>#</th>")
+	if r.times {
+		b.WriteString("<th>Time</th>")
+	}
+	b.WriteString("<th>Location</th><th>Message</th><th>Cause</th></tr></thead>\n<tbody>\n")
+	for i, frame := range r.frames {
+		b.WriteString("<tr><td>" + strconv.Itoa(i+1) + "</td>")
+		if r.times {
+			b.WriteString("<td>" + esc(frameTime(frame)) + "</td>")
+		}
+		b.WriteString("<td>")
+		if frame.Remote {
+			b.WriteString("remote: ")
+		}
+		if location := frameLocation(frame); location != "" {
+			b.WriteString("<code>" + esc(location) + "</code>")
+		}
+		b.WriteString("</td><td>" + esc(frame.Message) + "</td><td>" + esc(frame.Cause) + "</td></tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n</details>\n")
+	return b.String()
+}
+
+// htmlHint renders h as HTML, with a link to its URL if it is safe.
+func htmlHint(h Hint) string {
+	text := html.EscapeString(h.Text)
+	if h.URL == "" {
+		return text
+	}
+	link := html.EscapeString(h.URL)
+	if safeURL(h.URL) {
+		link = `<a href="` + link + `">` + link + "</a>"
+	}
+	if text == "" {
+		return link
+	}
+	return text + " " + link
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"context"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type reportSuite struct{}
+
+var _ = gc.Suite(&reportSuite{})
+
+func reportError(c *gc.C) (error, []string) {
+	err := errors.NotFoundf("unit %q", "<mysql|0>")
+	loc0 := errorLocationValue(c)
+	err = errors.With(err, "unit", "mysql/0")
+	loc1 := errorLocationValue(c)
+	err = errors.WithHint(err, "run `juju status`")
+	loc2 := errorLocationValue(c)
+	err = errors.WithHelpURL(err, "https://juju.is/docs?a=1&b=2")
+	loc3 := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	loc4 := errorLocationValue(c)
+	return err, []string{loc0, loc1, loc2, loc3, loc4}
+}
+
+func (*reportSuite) TestRenderMarkdown(c *gc.C) {
+	err, locs := reportError(c)
+	c.Assert(errors.RenderMarkdown(err), gc.Equals, ""+
+		"<details>\n"+
+		"<summary>cannot deploy: unit &#34;&lt;mysql|0&gt;&#34; not found</summary>\n"+
+		"\n"+
+		"- **Kinds:** not found\n"+
+		"- **Hints:**\n"+
+		"  - <https://juju.is/docs?a=1&b=2>\n"+
+		"  - run \\`juju status\\`\n"+
+		"- **Fields:**\n"+
+		"  - ` unit `: mysql/0\n"+
+		"\n"+
+		"| # | Location | Message | Cause |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| 1 | ` "+locs[0]+" ` | unit \"\\<mysql\\|0\\>\" not found |  |\n"+
+		"| 2 | ` "+locs[1]+" ` |  |  |\n"+
+		"| 3 | ` "+locs[2]+" ` |  |  |\n"+
+		"| 4 | ` "+locs[3]+" ` |  |  |\n"+
+		"| 5 | ` "+locs[4]+" ` | cannot deploy |  |\n"+
+		"\n"+
+		"</details>\n")
+}
+
+func (*reportSuite) TestRenderHTML(c *gc.C) {
+	err, locs := reportError(c)
+	c.Assert(errors.RenderHTML(err), gc.Equals, ""+
+		`<details class="error-report">`+"\n"+
+		"<summary>cannot deploy: unit &#34;&lt;mysql|0&gt;&#34; not found</summary>\n"+
+		"<dl>\n"+
+		"<dt>Kinds</dt><dd>not found</dd>\n"+
+		`<dt>Hints</dt><dd><ul><li><a href="https://juju.is/docs?a=1&amp;b=2">https://juju.is/docs?a=1&amp;b=2</a></li>`+
+		"<li>run `juju status`</li></ul></dd>\n"+
+		"<dt>Fields</dt><dd><ul><li><code>unit</code>: mysql/0</li></ul></dd>\n"+
+		"</dl>\n"+
+		"<table>\n"+
+		"<thead><tr><th>#</th><th>Location</th><th>Message</th><th>Cause</th></tr></thead>\n"+
+		"<tbody>\n"+
+		"<tr><td>1</td><td><code>"+locs[0]+"</code></td><td>unit &#34;&lt;mysql|0&gt;&#34; not found</td><td></td></tr>\n"+
+		"<tr><td>2</td><td><code>"+locs[1]+"</code></td><td></td><td></td></tr>\n"+
+		"<tr><td>3</td><td><code>"+locs[2]+"</code></td><td></td><td></td></tr>\n"+
+		"<tr><td>4</td><td><code>"+locs[3]+"</code></td><td></td><td></td></tr>\n"+
+		"<tr><td>5</td><td><code>"+locs[4]+"</code></td><td>cannot deploy</td><td></td></tr>\n"+
+		"</tbody>\n"+
+		"</table>\n"+
+		"</details>\n")
+}
+
+func (*reportSuite) TestRenderUnsafe(c *gc.C) {
+	err := errors.New("<script>alert(1)</script>")
+	err = errors.WithHelpURL(err, `javascript:alert("x")`)
+	err = errors.Wrap(err, errors.New("a | b"))
+
+	rendered := errors.RenderHTML(err)
+	c.Assert(rendered, gc.Not(gc.Matches), "(?s).*<script>.*")
+	c.Assert(rendered, gc.Not(gc.Matches), "(?s).*<a .*")
+	c.Assert(rendered, Contains, "javascript:alert(&#34;x&#34;)")
+
+	rendered = errors.RenderMarkdown(err)
+	c.Assert(rendered, Contains, `\<script\>alert(1)\</script\>`)
+	c.Assert(rendered, Contains, `| a \| b |`)
+	c.Assert(rendered, Contains, "  - javascript:alert(\"x\")\n")
+}
+
+func (*reportSuite) TestRenderTrace(c *gc.C) {
+	errors.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "4bf9`2f35", "", true
+	})
+	defer errors.SetTraceExtractor(nil)
+
+	err := errors.WithTraceContext(context.Background(), errors.New("boom"))
+	c.Assert(errors.RenderMarkdown(err), Contains, "- **Trace:** `` trace=4bf9`2f35 ``\n")
+	c.Assert(errors.RenderHTML(err), Contains, "<dt>Trace</dt><dd><code>trace=4bf9`2f35</code></dd>\n")
+}
+
+func (*reportSuite) TestRenderRemote(c *gc.C) {
+	err := errors.NewRemoteError(errors.New("boom"), errors.Origin{})
+	c.Assert(errors.RenderMarkdown(err), Contains, "| 1 | remote: ` ")
+	c.Assert(errors.RenderHTML(err), Contains, "<td>remote: <code>")
+	c.Assert(strings.Count(errors.RenderHTML(err), "<tr>"), gc.Equals, 2)
+}
+
+func (*reportSuite) TestRenderNil(c *gc.C) {
+	c.Assert(errors.RenderMarkdown(nil), gc.Equals, "")
+	c.Assert(errors.RenderHTML(nil), gc.Equals, "")
+}
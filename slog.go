@@ -0,0 +1,213 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+)
+
+// Verbosity controls how much detail about an error is included when it is
+// logged with log/slog.
+type Verbosity int
+
+const (
+	// VerbosityStack includes the error message, kinds, origin, attached
+	// fields and every frame of the error stack. It is the default.
+	VerbosityStack Verbosity = iota
+
+	// VerbosityOrigin includes the error message, kinds, origin and
+	// attached fields, but not the error stack.
+	VerbosityOrigin
+
+	// VerbosityMessage includes only the error message.
+	VerbosityMessage
+)
+
+var (
+	_ slog.LogValuer = (*Err)(nil)
+	_ slog.LogValuer = (*locationError)(nil)
+	_ slog.LogValuer = (*errWithType)(nil)
+	_ slog.LogValuer = (*PanicError)(nil)
+)
+
+// LogValue returns the slog group describing err that the LogValue methods of
+// the errors of this package return, holding its message, kinds, origin, stack
+// and attached fields.
+//
+// Types that embed Err inherit its LogValue method, which only describes the
+// embedded Err, so a type that has its own Error method or kinds should
+// implement slog.LogValuer with this function:
+//
+//	func (e *FooError) LogValue() slog.Value {
+//		return errors.LogValue(e)
+//	}
+func LogValue(err error) slog.Value {
+	return errorLogValue(err, VerbosityStack)
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group holding
+// its message, kinds, origin, stack and attached fields.
+func (e *Err) LogValue() slog.Value {
+	return errorLogValue(e, VerbosityStack)
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group holding
+// its message, kinds, origin, stack and attached fields.
+func (l *locationError) LogValue() slog.Value {
+	return errorLogValue(l, VerbosityStack)
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group holding
+// its message, kinds, origin, stack and attached fields.
+func (e *errWithType) LogValue() slog.Value {
+	return errorLogValue(e, VerbosityStack)
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group holding
+// its message, kinds, origin, stack and attached fields.
+func (p *PanicError) LogValue() slog.Value {
+	return errorLogValue(p, VerbosityStack)
+}
+
+// errorLogValue builds the slog group for err, with the amount of detail
+// controlled by verbosity. The group has the following attributes, which are
+// omitted when empty:
+//
+//	msg     the result of err.Error()
+//	kinds   the error types err satisfies, such as "not found"
+//	origin  the location of the originating error
+//	trace   the trace identifier attached with WithTraceContext
+//	span    the span identifier attached with WithTraceContext
+//	stack   the frames of the error stack, as rendered by ErrorStack
+//	fields  the fields attached to err with With
+func errorLogValue(err error, verbosity Verbosity) slog.Value {
+	attrs := []slog.Attr{slog.String("msg", err.Error())}
+	if verbosity == VerbosityMessage {
+		return slog.GroupValue(attrs...)
+	}
+
+	if kinds := errorKinds(err); len(kinds) > 0 {
+		names := make([]string, len(kinds))
+		for i, kind := range kinds {
+			names[i] = kind.Error()
+		}
+		attrs = append(attrs, slog.Any("kinds", names))
+	}
+	if function, line := origin(err); function != "" {
+		attrs = append(attrs, slog.String("origin", fmt.Sprintf("%s:%d", function, line)))
+	}
+	if tc, ok := attached[traceContext](err); ok {
+		attrs = append(attrs, slog.String("trace", tc.traceID))
+		if tc.spanID != "" {
+			attrs = append(attrs, slog.String("span", tc.spanID))
+		}
+	}
+	if verbosity == VerbosityStack {
+		attrs = append(attrs, slog.Any("stack", errorStack(err)))
+	}
+	if fields := Fields(err); len(fields) > 0 {
+		fieldAttrs := make([]any, len(fields))
+		for i, field := range fields {
+			fieldAttrs[i] = slog.Any(field.Key, field.Value)
+		}
+		attrs = append(attrs, slog.Group("fields", fieldAttrs...))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// origin returns the location of the innermost error in the chain of err that
+// recorded one.
+func origin(err error) (function string, line int) {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if l, ok := err.(Locationer); ok {
+			if f, n := l.Location(); f != "" {
+				function, line = f, n
+			}
+		}
+	}
+	return function, line
+}
+
+// SlogOptions are options for a slog.Handler created by NewSlogHandler.
+type SlogOptions struct {
+	// Verbosity controls how much detail is logged for each error-valued
+	// attribute. The zero value is VerbosityStack.
+	Verbosity Verbosity
+}
+
+// NewSlogHandler returns a slog.Handler that expands every error-valued
+// attribute into a group, in the same way as the LogValue method of the
+// errors created by this package, before passing the record on to next.
+// Unlike LogValue, errors of any type are expanded, including errors from
+// other packages that wrap errors from this one. If opts is nil, the default
+// options are used.
+//
+// For example:
+//
+//	logger := slog.New(errors.NewSlogHandler(slog.NewJSONHandler(os.Stderr, nil), nil))
+//	logger.Error("cannot deploy", "err", err)
+func NewSlogHandler(next slog.Handler, opts *SlogOptions) slog.Handler {
+	h := &slogHandler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// slogHandler is the slog.Handler returned by NewSlogHandler.
+type slogHandler struct {
+	next slog.Handler
+	opts SlogOptions
+}
+
+// Enabled implements slog.Handler.
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	expanded := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		expanded.AddAttrs(h.expand(a))
+		return true
+	})
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = h.expand(a)
+	}
+	return &slogHandler{next: h.next.WithAttrs(expanded), opts: h.opts}
+}
+
+// WithGroup implements slog.Handler.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+// expand replaces the value of a with a group describing the error if a
+// holds an error, looking inside groups.
+func (h *slogHandler) expand(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok && err != nil {
+			a.Value = errorLogValue(err, h.opts.Verbosity)
+		}
+	case slog.KindGroup:
+		group := a.Value.Group()
+		expanded := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			expanded[i] = h.expand(ga)
+		}
+		a.Value = slog.GroupValue(expanded...)
+	}
+	return a
+}
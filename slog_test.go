@@ -0,0 +1,181 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type slogSuite struct{}
+
+var _ = gc.Suite(&slogSuite{})
+
+// groupAttrs returns the attributes of a resolved group value keyed by name.
+func groupAttrs(c *gc.C, v slog.Value) map[string]slog.Value {
+	v = v.Resolve()
+	c.Assert(v.Kind(), gc.Equals, slog.KindGroup)
+	attrs := make(map[string]slog.Value)
+	for _, a := range v.Group() {
+		attrs[a.Key] = a.Value
+	}
+	return attrs
+}
+
+func (*slogSuite) TestErrLogValue(c *gc.C) {
+	err := errors.New("boom")
+	origin := errorLocationValue(c)
+	err = errors.With(err, "unit", "mysql/0")
+	err = errors.Annotate(err, "cannot deploy")
+
+	attrs := groupAttrs(c, slog.AnyValue(err))
+	c.Check(attrs["msg"].String(), gc.Equals, "cannot deploy: boom")
+	c.Check(attrs["origin"].String(), gc.Equals, origin)
+	c.Check(attrs["stack"].Any(), gc.HasLen, 3)
+	c.Check(attrs["stack"].Any().([]string)[0], gc.Equals, origin+": boom")
+	_, hasKinds := attrs["kinds"]
+	c.Check(hasKinds, gc.Equals, false)
+
+	fields := groupAttrs(c, attrs["fields"])
+	c.Check(fields["unit"].String(), gc.Equals, "mysql/0")
+}
+
+func (*slogSuite) TestTypedErrorLogValue(c *gc.C) {
+	for i, err := range []error{
+		errors.NotFoundf("unit %q", "mysql/0"),
+		errors.NewNotFound(fmt.Errorf("unit %q not found", "mysql/0"), ""),
+	} {
+		c.Logf("test %d: %T", i, err)
+		_, ok := err.(slog.LogValuer)
+		c.Assert(ok, gc.Equals, true)
+
+		attrs := groupAttrs(c, slog.AnyValue(err))
+		c.Check(attrs["msg"].String(), gc.Equals, `unit "mysql/0" not found`)
+		c.Check(attrs["kinds"].Any(), gc.DeepEquals, []string{"not found"})
+		c.Check(attrs["origin"].String(), Contains, "TestTypedErrorLogValue")
+	}
+}
+
+// deployError is an error type embedding Err with its own message.
+type deployError struct {
+	errors.Err
+	unit string
+}
+
+func (e *deployError) Error() string {
+	return "cannot deploy " + e.unit + ": " + e.Err.Error()
+}
+
+func (e *deployError) LogValue() slog.Value {
+	return errors.LogValue(e)
+}
+
+func (*slogSuite) TestEmbeddingErrLogValue(c *gc.C) {
+	deployErr := &deployError{Err: errors.NewErr("unit not found"), unit: "mysql/0"}
+	deployErr.SetLocation(0)
+	panicErr := errors.Recover(func() error { panic(errors.NotFoundf("unit")) })
+	for i, test := range []struct {
+		err   error
+		msg   string
+		kinds []string
+	}{{
+		err: deployErr,
+		msg: "cannot deploy mysql/0: unit not found",
+	}, {
+		err:   panicErr,
+		msg:   "panic: unit not found",
+		kinds: []string{"panic", "not found"},
+	}} {
+		c.Logf("test %d: %T", i, test.err)
+		attrs := groupAttrs(c, slog.AnyValue(test.err))
+		c.Check(attrs["msg"].String(), gc.Equals, test.msg)
+		if test.kinds != nil {
+			c.Check(attrs["kinds"].Any(), gc.DeepEquals, test.kinds)
+		}
+
+		var buf bytes.Buffer
+		logger := slog.New(errors.NewSlogHandler(slog.NewJSONHandler(&buf, nil), nil))
+		logger.Error("failed", "err", test.err)
+		var record struct {
+			Err struct {
+				Msg   string   `json:"msg"`
+				Kinds []string `json:"kinds"`
+			} `json:"err"`
+		}
+		c.Assert(json.Unmarshal(buf.Bytes(), &record), gc.IsNil)
+		c.Check(record.Err.Msg, gc.Equals, test.msg)
+		c.Check(record.Err.Kinds, gc.DeepEquals, test.kinds)
+	}
+}
+
+func (*slogSuite) TestHandlerExpandsErrors(c *gc.C) {
+	var buf bytes.Buffer
+	handler := errors.NewSlogHandler(slog.NewJSONHandler(&buf, nil), nil)
+	logger := slog.New(handler).With("model", "default")
+
+	err := errors.NotFoundf("unit")
+	wrapped := fmt.Errorf("wrapped: %w", err)
+	logger.WithGroup("deploy").Error("failed", "err", wrapped, "attempt", 2)
+
+	var record struct {
+		Msg    string `json:"msg"`
+		Model  string `json:"model"`
+		Deploy struct {
+			Attempt int `json:"attempt"`
+			Err     struct {
+				Msg   string   `json:"msg"`
+				Kinds []string `json:"kinds"`
+				Stack []string `json:"stack"`
+			} `json:"err"`
+		} `json:"deploy"`
+	}
+	c.Assert(json.Unmarshal(buf.Bytes(), &record), gc.IsNil)
+	c.Check(record.Msg, gc.Equals, "failed")
+	c.Check(record.Model, gc.Equals, "default")
+	c.Check(record.Deploy.Attempt, gc.Equals, 2)
+	c.Check(record.Deploy.Err.Msg, gc.Equals, "wrapped: unit not found")
+	c.Check(record.Deploy.Err.Kinds, gc.DeepEquals, []string{"not found"})
+	c.Check(record.Deploy.Err.Stack, gc.HasLen, 1)
+}
+
+func (*slogSuite) TestHandlerVerbosity(c *gc.C) {
+	err := errors.Annotate(errors.NotFoundf("unit"), "cannot deploy")
+	for i, test := range []struct {
+		verbosity errors.Verbosity
+		keys      []string
+	}{{
+		verbosity: errors.VerbosityStack,
+		keys:      []string{"kinds", "msg", "origin", "stack"},
+	}, {
+		verbosity: errors.VerbosityOrigin,
+		keys:      []string{"kinds", "msg", "origin"},
+	}, {
+		verbosity: errors.VerbosityMessage,
+		keys:      []string{"msg"},
+	}} {
+		c.Logf("test %d: verbosity %d", i, test.verbosity)
+		var buf bytes.Buffer
+		opts := &errors.SlogOptions{Verbosity: test.verbosity}
+		logger := slog.New(errors.NewSlogHandler(slog.NewJSONHandler(&buf, nil), opts))
+		logger.Error("failed", "err", err)
+
+		var record struct {
+			Err map[string]interface{} `json:"err"`
+		}
+		c.Assert(json.Unmarshal(buf.Bytes(), &record), gc.IsNil)
+		var keys []string
+		for key := range record.Err {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		c.Check(keys, gc.DeepEquals, test.keys)
+	}
+}
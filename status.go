@@ -0,0 +1,343 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status codes of the google.rpc.Code enumeration, as used by gRPC.
+const (
+	codeOK                 = 0
+	codeUnknown            = 2
+	codeInvalidArgument    = 3
+	codeDeadlineExceeded   = 4
+	codeNotFound           = 5
+	codeAlreadyExists      = 6
+	codePermissionDenied   = 7
+	codeResourceExhausted  = 8
+	codeFailedPrecondition = 9
+	codeUnimplemented      = 12
+	codeInternal           = 13
+	codeUnavailable        = 14
+	codeUnauthenticated    = 16
+)
+
+// kindCodes maps the error types of this package to status codes.
+var kindCodes = map[ConstError]int32{
+	Timeout:            codeDeadlineExceeded,
+	NotFound:           codeNotFound,
+	UserNotFound:       codeNotFound,
+	Unauthorized:       codeUnauthenticated,
+	NotImplemented:     codeUnimplemented,
+	AlreadyExists:      codeAlreadyExists,
+	NotSupported:       codeUnimplemented,
+	NotValid:           codeInvalidArgument,
+	NotProvisioned:     codeFailedPrecondition,
+	NotAssigned:        codeFailedPrecondition,
+	BadRequest:         codeInvalidArgument,
+	MethodNotAllowed:   codeUnimplemented,
+	Forbidden:          codePermissionDenied,
+	QuotaLimitExceeded: codeResourceExhausted,
+	NotYetAvailable:    codeUnavailable,
+	Panic:              codeInternal,
+}
+
+// codeKinds maps status codes to the error types that decoded errors satisfy.
+var codeKinds = map[int32]ConstError{
+	codeInvalidArgument:   NotValid,
+	codeDeadlineExceeded:  Timeout,
+	codeNotFound:          NotFound,
+	codeAlreadyExists:     AlreadyExists,
+	codePermissionDenied:  Forbidden,
+	codeResourceExhausted: QuotaLimitExceeded,
+	codeUnimplemented:     NotImplemented,
+	codeUnavailable:       NotYetAvailable,
+	codeUnauthenticated:   Unauthorized,
+}
+
+// StatusCode returns the google.rpc.Code, as used by gRPC, for the outermost
+// error type in the chain of err that has one: for example 5 (NOT_FOUND) for
+// NotFound and 16 (UNAUTHENTICATED) for Unauthorized. It returns 0 (OK) if err
+// is nil, and 2 (UNKNOWN) if err has no such error type.
+func StatusCode(err error) int32 {
+	if err == nil {
+		return codeOK
+	}
+	for _, kind := range errorKinds(err) {
+		if code, ok := kindCodes[kind]; ok {
+			return code
+		}
+	}
+	return codeUnknown
+}
+
+// retryDelay is the attachment recorded by WithRetryDelay.
+type retryDelay time.Duration
+
+// WithRetryDelay attaches to err the delay after which the failed operation
+// may be retried, and records the location of the WithRetryDelay call, much
+// like Trace. The delay is available from RetryDelay, and is encoded as a
+// google.rpc.RetryInfo by EncodeStatus. If err is nil, the result will be nil.
+func WithRetryDelay(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: retryDelay(delay),
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// RetryDelay returns the retry delay attached to err by the outermost call to
+// WithRetryDelay in its chain, and whether there is one.
+func RetryDelay(err error) (time.Duration, bool) {
+	delay, ok := attached[retryDelay](err)
+	return time.Duration(delay), ok
+}
+
+// Type URLs of the details of a google.rpc.Status.
+const (
+	typeURLPrefix     = "type.googleapis.com/"
+	errorInfoTypeURL  = typeURLPrefix + "google.rpc.ErrorInfo"
+	retryInfoTypeURL  = typeURLPrefix + "google.rpc.RetryInfo"
+	debugInfoTypeURL  = typeURLPrefix + "google.rpc.DebugInfo"
+	errorChainTypeURL = typeURLPrefix + "juju.errors.v1.ErrorChain"
+)
+
+// EncodeStatus encodes err in the protocol buffers wire format of a
+// google.rpc.Status, as used by gRPC and by Google APIs. The status has:
+//
+//   - the code returned by StatusCode;
+//   - the error string of err as the message;
+//   - a google.rpc.ErrorInfo detail, if one is attached with WithErrorInfo;
+//   - a google.rpc.RetryInfo detail, if a delay is attached with
+//     WithRetryDelay;
+//   - a google.rpc.DebugInfo detail, with the lines of ErrorStack as the
+//     stack entries;
+//   - a juju.errors.v1.ErrorChain detail, with the encoding of err by
+//     EncodeJSON as its field 1, allowing DecodeStatus to decode the full
+//     chain.
+//
+// The encoding of a nil error is empty, which is an OK status.
+func EncodeStatus(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	chain, encodeErr := EncodeJSON(err)
+	if encodeErr != nil {
+		return nil, Trace(encodeErr)
+	}
+
+	var status protoEncoder
+	status.varint(1, int64(StatusCode(err)))
+	status.string(2, err.Error())
+	if info, ok := Info(err); ok {
+		var m protoEncoder
+		m.string(1, info.Reason)
+		m.string(2, info.Domain)
+		for _, key := range sortedKeys(info.Metadata) {
+			var entry protoEncoder
+			entry.string(1, key)
+			entry.string(2, info.Metadata[key])
+			m.message(3, entry)
+		}
+		status.message(3, statusDetail(errorInfoTypeURL, m))
+	}
+	if delay, ok := RetryDelay(err); ok {
+		var d, m protoEncoder
+		d.varint(1, int64(delay/time.Second))
+		d.varint(2, int64(delay%time.Second))
+		m.message(1, d)
+		status.message(3, statusDetail(retryInfoTypeURL, m))
+	}
+	var debug protoEncoder
+	for _, line := range strings.Split(ErrorStack(err), "\n") {
+		debug.string(1, line)
+	}
+	status.message(3, statusDetail(debugInfoTypeURL, debug))
+	var m protoEncoder
+	m.bytes(1, chain)
+	status.message(3, statusDetail(errorChainTypeURL, m))
+	return status.buf, nil
+}
+
+// statusDetail returns a google.protobuf.Any holding the message m of the
+// type identified by typeURL.
+func statusDetail(typeURL string, m protoEncoder) protoEncoder {
+	var detail protoEncoder
+	detail.string(1, typeURL)
+	detail.bytes(2, m.buf)
+	return detail
+}
+
+// DecodeStatus decodes a google.rpc.Status in the protocol buffers wire
+// format. The result is a RemoteError, or nil if the status code is 0 (OK).
+//
+// If the status was encoded by EncodeStatus, the error is decoded with its
+// full chain and origin, as with DecodeJSON. Otherwise, the remote error has
+// the message of the status, satisfies the error type corresponding to its
+// code, if any, and has the ErrorInfo and retry delay of its details
+// attached.
+func DecodeStatus(data []byte) (error, error) {
+	var (
+		code    int32
+		message string
+		info    *ErrorInfo
+		delay   *time.Duration
+		chain   []byte
+	)
+	err := decodeProto(data, func(f protoField) error {
+		switch {
+		case f.number == 1 && f.wireType == protoVarint:
+			code = int32(f.varint)
+		case f.number == 2 && f.wireType == protoBytes:
+			message = string(f.bytes)
+		case f.number == 3 && f.wireType == protoBytes:
+			typeURL, value, err := decodeStatusDetail(f.bytes)
+			if err != nil {
+				return err
+			}
+			switch typeURL {
+			case errorInfoTypeURL:
+				if info, err = decodeErrorInfo(value); err != nil {
+					return err
+				}
+			case retryInfoTypeURL:
+				if delay, err = decodeRetryInfo(value); err != nil {
+					return err
+				}
+			case errorChainTypeURL:
+				err := decodeProto(value, func(f protoField) error {
+					if f.number == 1 && f.wireType == protoBytes {
+						chain = f.bytes
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Annotate(err, "decoding status")
+	}
+	if code == codeOK {
+		return nil, nil
+	}
+	if chain != nil {
+		decoded, err := DecodeJSON(chain)
+		if err != nil {
+			return nil, Annotate(err, "decoding status")
+		}
+		return decoded, nil
+	}
+
+	var decoded error = &Err{message: message}
+	if kind, ok := codeKinds[code]; ok {
+		decoded = &errWithType{error: decoded, errType: kind}
+	}
+	if info != nil {
+		decoded = &Err{previous: decoded, cause: Cause(decoded), attachment: *info}
+	}
+	if delay != nil {
+		decoded = &Err{previous: decoded, cause: Cause(decoded), attachment: retryDelay(*delay)}
+	}
+	return NewRemoteError(decoded, Origin{}), nil
+}
+
+// sortedKeys returns the keys of m in order, so that encodings are
+// deterministic.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeStatusDetail decodes a google.protobuf.Any, returning its type URL
+// and the encoded message it holds.
+func decodeStatusDetail(data []byte) (typeURL string, value []byte, err error) {
+	err = decodeProto(data, func(f protoField) error {
+		switch {
+		case f.number == 1 && f.wireType == protoBytes:
+			typeURL = string(f.bytes)
+		case f.number == 2 && f.wireType == protoBytes:
+			value = f.bytes
+		}
+		return nil
+	})
+	return typeURL, value, err
+}
+
+// decodeErrorInfo decodes a google.rpc.ErrorInfo.
+func decodeErrorInfo(data []byte) (*ErrorInfo, error) {
+	var info ErrorInfo
+	err := decodeProto(data, func(f protoField) error {
+		if f.wireType != protoBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			info.Reason = string(f.bytes)
+		case 2:
+			info.Domain = string(f.bytes)
+		case 3:
+			var key, value string
+			err := decodeProto(f.bytes, func(f protoField) error {
+				switch {
+				case f.number == 1 && f.wireType == protoBytes:
+					key = string(f.bytes)
+				case f.number == 2 && f.wireType == protoBytes:
+					value = string(f.bytes)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if info.Metadata == nil {
+				info.Metadata = make(map[string]string)
+			}
+			info.Metadata[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// decodeRetryInfo decodes a google.rpc.RetryInfo, returning its retry delay.
+func decodeRetryInfo(data []byte) (*time.Duration, error) {
+	var delay time.Duration
+	err := decodeProto(data, func(f protoField) error {
+		if f.number != 1 || f.wireType != protoBytes {
+			return nil
+		}
+		return decodeProto(f.bytes, func(f protoField) error {
+			switch {
+			case f.number == 1 && f.wireType == protoVarint:
+				delay += time.Duration(int64(f.varint)) * time.Second
+			case f.number == 2 && f.wireType == protoVarint:
+				delay += time.Duration(int32(f.varint))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delay, nil
+}
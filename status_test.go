@@ -0,0 +1,134 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"strings"
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type statusSuite struct{}
+
+var _ = gc.Suite(&statusSuite{})
+
+// protoString returns a length-delimited protobuf field with the given key,
+// for values shorter than 128 bytes.
+func protoString(key byte, value string) string {
+	return string([]byte{key, byte(len(value))}) + value
+}
+
+func (*statusSuite) TestStatusCode(c *gc.C) {
+	c.Assert(errors.StatusCode(nil), gc.Equals, int32(0))
+	c.Assert(errors.StatusCode(errors.New("boom")), gc.Equals, int32(2))
+	c.Assert(errors.StatusCode(errors.NotFoundf("unit")), gc.Equals, int32(5))
+	c.Assert(errors.StatusCode(errors.Trace(errors.Unauthorizedf("token"))), gc.Equals, int32(16))
+	c.Assert(errors.StatusCode(errors.Timeoutf("connecting")), gc.Equals, int32(4))
+
+	err := errors.NewForbidden(errors.NotFoundf("unit"), "go away")
+	c.Assert(errors.StatusCode(err), gc.Equals, int32(7))
+	err = errors.WithType(errors.New("boom"), errors.ConstError("custom"))
+	c.Assert(errors.StatusCode(err), gc.Equals, int32(2))
+}
+
+func (*statusSuite) TestRetryDelay(c *gc.C) {
+	err := errors.WithRetryDelay(errors.QuotaLimitExceededf("requests"), 1500*time.Millisecond)
+	delay, ok := errors.RetryDelay(err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(delay, gc.Equals, 1500*time.Millisecond)
+	c.Assert(err.Error(), gc.Equals, "requests")
+	c.Assert(errors.Is(err, errors.QuotaLimitExceeded), gc.Equals, true)
+
+	_, ok = errors.RetryDelay(errors.New("boom"))
+	c.Assert(ok, gc.Equals, false)
+	c.Assert(errors.WithRetryDelay(nil, time.Second), gc.IsNil)
+}
+
+func (*statusSuite) TestRoundTrip(c *gc.C) {
+	err := errors.NotFoundf("unit %q", "mysql/0")
+	err = errors.WithErrorInfo(err, errors.ErrorInfo{Reason: "UNIT_NOT_FOUND", Domain: "juju"})
+	err = errors.WithRetryDelay(err, 2*time.Second)
+	err = errors.Annotate(err, "cannot deploy")
+
+	data, encodeErr := errors.EncodeStatus(err)
+	c.Assert(encodeErr, gc.IsNil)
+	c.Assert(string(data), Contains, "\x08\x05"+protoString(0x12, err.Error()))
+	c.Assert(string(data), Contains, "type.googleapis.com/google.rpc.DebugInfo")
+	c.Assert(string(data), Contains, strings.Split(errors.ErrorStack(err), "\n")[0])
+
+	decoded, decodeErr := errors.DecodeStatus(data)
+	c.Assert(decodeErr, gc.IsNil)
+	c.Assert(decoded.Error(), gc.Equals, err.Error())
+	c.Assert(errors.ErrorStack(decoded), gc.Equals, remoteStack(err))
+	c.Assert(errors.Is(decoded, errors.NotFound), gc.Equals, true)
+	delay, _ := errors.RetryDelay(decoded)
+	c.Assert(delay, gc.Equals, 2*time.Second)
+}
+
+func (*statusSuite) TestRoundTripPanic(c *gc.C) {
+	err := errors.Recover(func() error { panic("boom") })
+	err = errors.Annotate(err, "cannot deploy")
+
+	data, encodeErr := errors.EncodeStatus(err)
+	c.Assert(encodeErr, gc.IsNil)
+	c.Assert(string(data), Contains, "\x08\x0d"+protoString(0x12, err.Error()))
+
+	decoded, decodeErr := errors.DecodeStatus(data)
+	c.Assert(decodeErr, gc.IsNil)
+	c.Assert(decoded.Error(), gc.Equals, err.Error())
+	c.Assert(errors.ErrorStack(decoded), gc.Equals, remoteStack(err))
+	c.Assert(errors.Is(decoded, errors.Panic), gc.Equals, true)
+	c.Assert(errors.StatusCode(decoded), gc.Equals, int32(13))
+
+	// Other INTERNAL statuses are not panics.
+	decoded, decodeErr = errors.DecodeStatus([]byte("\x08\x0d" + protoString(0x12, "database corrupted")))
+	c.Assert(decodeErr, gc.IsNil)
+	c.Assert(decoded, gc.ErrorMatches, "database corrupted")
+	c.Assert(errors.Is(decoded, errors.Panic), gc.Equals, false)
+}
+
+func (*statusSuite) TestNil(c *gc.C) {
+	data, err := errors.EncodeStatus(nil)
+	c.Assert(err, gc.IsNil)
+	c.Assert(data, gc.HasLen, 0)
+	decoded, err := errors.DecodeStatus(data)
+	c.Assert(err, gc.IsNil)
+	c.Assert(decoded, gc.IsNil)
+}
+
+func (*statusSuite) TestDecodeForeign(c *gc.C) {
+	errorInfo := protoString(0x0a, "RATE_LIMITED") + protoString(0x12, "example.com") +
+		protoString(0x1a, protoString(0x0a, "quota")+protoString(0x12, "requests"))
+	retryInfo := protoString(0x0a, "\x08\x01\x10\x80\xca\xb5\xee\x01")
+	status := "\x08\x08" + protoString(0x12, "too many requests") +
+		protoString(0x1a, protoString(0x0a, "type.googleapis.com/google.rpc.ErrorInfo")+protoString(0x12, errorInfo)) +
+		protoString(0x1a, protoString(0x0a, "type.googleapis.com/google.rpc.RetryInfo")+protoString(0x12, retryInfo)) +
+		protoString(0x1a, protoString(0x0a, "type.googleapis.com/example.Unknown")) +
+		"\x25\x01\x02\x03\x04" // unknown fixed32 field
+
+	decoded, err := errors.DecodeStatus([]byte(status))
+	c.Assert(err, gc.IsNil)
+	c.Assert(decoded, gc.ErrorMatches, "too many requests")
+	c.Assert(errors.Is(decoded, errors.QuotaLimitExceeded), gc.Equals, true)
+	c.Assert(errors.StatusCode(decoded), gc.Equals, int32(8))
+	info, ok := errors.Info(decoded)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(info, gc.DeepEquals, errors.ErrorInfo{
+		Reason:   "RATE_LIMITED",
+		Domain:   "example.com",
+		Metadata: map[string]string{"quota": "requests"},
+	})
+	delay, ok := errors.RetryDelay(decoded)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(delay, gc.Equals, 1500*time.Millisecond)
+}
+
+func (*statusSuite) TestDecodeInvalid(c *gc.C) {
+	_, err := errors.DecodeStatus([]byte("\x12\x10short"))
+	c.Assert(err, gc.ErrorMatches, "decoding status: protobuf length in field 2 not valid")
+	c.Assert(errors.Is(err, errors.NotValid), gc.Equals, true)
+}
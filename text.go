@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+)
+
+// textOptions controls how the text of an error is rendered by the errors of
+// this package. The zero value renders the same text as their Error methods.
+type textOptions struct {
+	// reveal shows the original values of arguments marked by Secret.
+	reveal bool
+
+	// catalog, if not nil, is used to translate messages and kinds into
+	// lang.
+	catalog *Catalog
+	lang    string
+
+	// collapse collapses consecutive identical annotations.
+	collapse bool
+}
+
+// renderOptions returns the options for rendering err as its Error method
+// does, as determined by the policy and by any call to CollapseDuplicates in
+// its chain.
+func renderOptions(err error) textOptions {
+	o := textOptions{collapse: CurrentPolicy().CollapseDuplicates}
+	if !o.collapse {
+		_, o.collapse = attached[collapseDuplicates](err)
+	}
+	return o
+}
+
+// isPlain reports whether o renders the same text as the Error method would
+// without collapsing annotations.
+func (o textOptions) isPlain() bool {
+	return !o.reveal && o.catalog == nil && !o.collapse
+}
+
+// translate returns the template for the message with the given id in the
+// language of o, or id itself if there is no translation.
+func (o textOptions) translate(id string) (string, bool) {
+	if o.catalog == nil {
+		return id, false
+	}
+	return o.catalog.message(o.lang, id)
+}
+
+// kind returns the text of kind in the language of o.
+func (o textOptions) kind(kind ConstError) string {
+	if o.catalog == nil {
+		return string(kind)
+	}
+	return o.catalog.kind(o.lang, kind)
+}
+
+// message renders a message created with fmt.Sprintf(format, args...), where
+// text is the message as it was originally rendered. If format is empty, text
+// is a constant message that is used as its own message ID.
+func (o textOptions) message(text, format string, args []interface{}) string {
+	if o.isPlain() {
+		return text
+	}
+	if format == "" {
+		template, _ := o.translate(text)
+		return template
+	}
+	template, translated := o.translate(format)
+	if !translated && !(o.reveal && hasSecrets(args)) {
+		return text
+	}
+	if o.reveal {
+		args = reveal(args)
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// textRenderer is implemented by the errors of this package that are able to
+// render their error string with text options.
+type textRenderer interface {
+	renderText(o textOptions) string
+}
+
+// messageRenderer is implemented by the errors of this package that are able
+// to render the message returned by their Message method with text options.
+type messageRenderer interface {
+	renderMessage(o textOptions) string
+}
+
+// errorString returns the error string of err rendered with o, if err
+// supports it.
+func errorString(err error, o textOptions) string {
+	if r, ok := err.(textRenderer); ok && (!o.isPlain() || limitsLength(err)) {
+		return r.renderText(o)
+	}
+	return err.Error()
+}
+
+// joinMessage renders err annotated with message, as "message: err".
+func joinMessage(message string, err error, o textOptions) string {
+	if r, ok := err.(textRenderer); ok && (!o.isPlain() || limitsLength(err)) {
+		return message + ": " + r.renderText(o)
+	}
+	return fmt.Sprintf("%s: %v", message, err)
+}
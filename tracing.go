@@ -0,0 +1,97 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// TraceExtractor returns the identifiers of the distributed trace and span
+// that are active in ctx, if any. It allows errors to be correlated with a
+// tracing system such as OpenTelemetry without this package depending on it.
+//
+// For example, with OpenTelemetry:
+//
+//	errors.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+//	    sc := trace.SpanContextFromContext(ctx)
+//	    return sc.TraceID().String(), sc.SpanID().String(), sc.IsValid()
+//	})
+type TraceExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+var traceExtractor atomic.Pointer[TraceExtractor]
+
+// SetTraceExtractor sets the function used by WithTraceContext to find the
+// trace and span identifiers in a context. Passing nil removes it.
+func SetTraceExtractor(extractor TraceExtractor) {
+	if extractor == nil {
+		traceExtractor.Store(nil)
+		return
+	}
+	traceExtractor.Store(&extractor)
+}
+
+// traceContext is the attachment recorded by WithTraceContext.
+type traceContext struct {
+	traceID string
+	spanID  string
+}
+
+// String returns the identifiers in the format used by ErrorStack and
+// Details.
+func (t traceContext) String() string {
+	s := "trace=" + t.traceID
+	if t.spanID != "" {
+		s += " span=" + t.spanID
+	}
+	return s
+}
+
+// WithTraceContext attaches the trace and span identifiers found in ctx by
+// the function set with SetTraceExtractor to err, and records the location of
+// the WithTraceContext call, much like Trace. The identifiers are available
+// from TraceID and SpanID, and are shown by ErrorStack and Details. If err is
+// nil, the result will be nil. If no extractor has been set or ctx has no
+// active trace, err is returned unchanged.
+//
+// For example:
+//
+//	if err := deploy(ctx, unit); err != nil {
+//	    return errors.WithTraceContext(ctx, err)
+//	}
+func WithTraceContext(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	extractor := traceExtractor.Load()
+	if extractor == nil {
+		return err
+	}
+	traceID, spanID, ok := (*extractor)(ctx)
+	if !ok || traceID == "" {
+		return err
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: traceContext{traceID: traceID, spanID: spanID},
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// TraceID returns the identifier of the distributed trace attached to err by
+// the outermost call to WithTraceContext in its chain, or the empty string if
+// there is none.
+func TraceID(err error) string {
+	tc, _ := attached[traceContext](err)
+	return tc.traceID
+}
+
+// SpanID returns the identifier of the span attached to err by the outermost
+// call to WithTraceContext in its chain, or the empty string if there is none.
+func SpanID(err error) string {
+	tc, _ := attached[traceContext](err)
+	return tc.spanID
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type tracingSuite struct{}
+
+var _ = gc.Suite(&tracingSuite{})
+
+type spanKey struct{}
+
+type span struct {
+	traceID, spanID string
+}
+
+func (*tracingSuite) SetUpTest(c *gc.C) {
+	errors.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		s, ok := ctx.Value(spanKey{}).(span)
+		return s.traceID, s.spanID, ok
+	})
+}
+
+func (*tracingSuite) TearDownTest(c *gc.C) {
+	errors.SetTraceExtractor(nil)
+}
+
+func (*tracingSuite) TestWithTraceContext(c *gc.C) {
+	ctx := context.WithValue(context.Background(), spanKey{}, span{"4bf92f35", "00f067aa"})
+	first := errors.NotFoundf("unit")
+	err := errors.WithTraceContext(ctx, first)
+	loc := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+
+	c.Assert(err.Error(), gc.Equals, "cannot deploy: unit not found")
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+	c.Assert(errors.TraceID(err), gc.Equals, "4bf92f35")
+	c.Assert(errors.SpanID(err), gc.Equals, "00f067aa")
+	c.Assert(errors.Details(err), gc.Matches, `trace=4bf92f35 span=00f067aa \[\{.*\}\]`)
+	c.Assert(errors.Details(err), Contains, loc)
+
+	lines := strings.Split(errors.ErrorStack(err), "\n")
+	c.Assert(lines, gc.HasLen, 4)
+	c.Assert(lines[0], gc.Equals, "trace=4bf92f35 span=00f067aa")
+	c.Assert(lines[2], gc.Equals, loc+": ")
+
+	attrs := groupAttrs(c, slog.AnyValue(err))
+	c.Assert(attrs["trace"].String(), gc.Equals, "4bf92f35")
+	c.Assert(attrs["span"].String(), gc.Equals, "00f067aa")
+}
+
+func (*tracingSuite) TestOutermostTraceContext(c *gc.C) {
+	inner := context.WithValue(context.Background(), spanKey{}, span{"inner", ""})
+	outer := context.WithValue(context.Background(), spanKey{}, span{"outer", "1"})
+	err := errors.WithTraceContext(inner, errors.New("boom"))
+	c.Assert(errors.ErrorStack(err), gc.Matches, "(?s)trace=inner\n.*")
+	err = errors.WithTraceContext(outer, errors.Trace(err))
+	c.Assert(errors.TraceID(err), gc.Equals, "outer")
+	c.Assert(errors.SpanID(err), gc.Equals, "1")
+}
+
+func (*tracingSuite) TestWithTraceContextNoTrace(c *gc.C) {
+	err := errors.New("boom")
+	c.Assert(errors.WithTraceContext(context.Background(), err), gc.Equals, err)
+	c.Assert(errors.WithTraceContext(context.Background(), nil), gc.IsNil)
+	c.Assert(errors.TraceID(err), gc.Equals, "")
+	c.Assert(errors.SpanID(err), gc.Equals, "")
+
+	errors.SetTraceExtractor(nil)
+	ctx := context.WithValue(context.Background(), spanKey{}, span{"4bf92f35", "00f067aa"})
+	c.Assert(errors.WithTraceContext(ctx, err), gc.Equals, err)
+}
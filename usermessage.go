@@ -0,0 +1,76 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+// genericUserMessage is the user message for errors that have neither a user
+// message nor a kind with a default user message.
+const genericUserMessage = "an internal error occurred"
+
+// defaultUserMessages holds the user message used for each kind of error when
+// no user message has been attached.
+var defaultUserMessages = map[ConstError]string{
+	Timeout:            "the operation timed out",
+	NotFound:           "the requested resource was not found",
+	UserNotFound:       "the user was not found",
+	Unauthorized:       "you are not authorized to perform this operation",
+	NotImplemented:     "this operation is not implemented",
+	AlreadyExists:      "the resource already exists",
+	NotSupported:       "this operation is not supported",
+	NotValid:           "the request is not valid",
+	NotProvisioned:     "the resource is not yet provisioned",
+	NotAssigned:        "the resource is not yet assigned",
+	BadRequest:         "the request is not valid",
+	MethodNotAllowed:   "this operation is not allowed",
+	Forbidden:          "you do not have permission to perform this operation",
+	QuotaLimitExceeded: "a quota limit has been exceeded",
+	NotYetAvailable:    "the resource is not yet available, try again later",
+}
+
+// userMessage is the attachment recorded by WithUserMessage.
+type userMessage string
+
+// WithUserMessage attaches msg to err as the message to show to end users, and
+// records the location of the WithUserMessage call, much like Trace. The user
+// message is kept separate from the internal detail of err: the result of
+// Error, ErrorStack and Details is unchanged, as are the Cause and kinds of
+// err. If err is nil, the result will be nil.
+//
+// For example:
+//
+//	if err := st.AddUser(name); err != nil {
+//	    return errors.WithUserMessage(err, "the user could not be created")
+//	}
+func WithUserMessage(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: userMessage(msg),
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// UserMessage returns a message describing err that is safe to show to end
+// users. This is the message attached by the outermost call to
+// WithUserMessage in the chain of err. If no user message has been attached,
+// a generic message for the outermost kind of error err satisfies is returned,
+// such as "the requested resource was not found" for NotFound, falling back to
+// "an internal error occurred". The empty string is returned if err is nil.
+func UserMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	if msg, ok := attached[userMessage](err); ok {
+		return string(msg)
+	}
+	for _, kind := range errorKinds(err) {
+		if msg, ok := defaultUserMessages[kind]; ok {
+			return msg
+		}
+	}
+	return genericUserMessage
+}
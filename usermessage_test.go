@@ -0,0 +1,64 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	stderrors "errors"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type userMessageSuite struct{}
+
+var _ = gc.Suite(&userMessageSuite{})
+
+func (*userMessageSuite) TestWithUserMessageNil(c *gc.C) {
+	c.Assert(errors.WithUserMessage(nil, "oops"), gc.IsNil)
+	c.Assert(errors.UserMessage(nil), gc.Equals, "")
+}
+
+func (*userMessageSuite) TestWithUserMessage(c *gc.C) {
+	first := errors.NotFoundf("user %q in table users", "bob")
+	err := errors.WithUserMessage(first, "the user could not be found")
+	loc := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot log in")
+
+	c.Assert(errors.UserMessage(err), gc.Equals, "the user could not be found")
+	c.Assert(err.Error(), gc.Equals, `cannot log in: user "bob" in table users not found`)
+	c.Assert(errors.ErrorStack(err), Contains, loc)
+	c.Assert(errors.Is(err, errors.NotFound), gc.Equals, true)
+}
+
+func (*userMessageSuite) TestOutermostUserMessage(c *gc.C) {
+	err := errors.WithUserMessage(errors.New("boom"), "inner")
+	err = errors.WithUserMessage(errors.Trace(err), "outer")
+	c.Assert(errors.UserMessage(err), gc.Equals, "outer")
+}
+
+func (*userMessageSuite) TestUserMessageDefaults(c *gc.C) {
+	for i, test := range []struct {
+		err      error
+		expected string
+	}{{
+		err:      errors.NotFoundf("table users"),
+		expected: "the requested resource was not found",
+	}, {
+		err:      errors.Annotate(errors.NewForbidden(nil, "secret detail"), "context"),
+		expected: "you do not have permission to perform this operation",
+	}, {
+		err:      errors.Unauthorizedf("token expired"),
+		expected: "you are not authorized to perform this operation",
+	}, {
+		err:      errors.WithType(errors.NotFoundf("x"), errors.ConstError("custom")),
+		expected: "the requested resource was not found",
+	}, {
+		err:      stderrors.New("database exploded"),
+		expected: "an internal error occurred",
+	}} {
+		c.Logf("test %d: %v", i, test.err)
+		c.Check(errors.UserMessage(test.err), gc.Equals, test.expected)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors
+
+// valueAttachment is the attachment recorded by WithValue. The value is boxed
+// so that it cannot be confused with the attachments used internally by this
+// package, and so that it is found by the type it was attached with.
+type valueAttachment[T any] struct {
+	value T
+}
+
+// WithValue attaches v to err so that it can later be retrieved by its type
+// with Value, and records the location of the WithValue call, much like
+// Trace. This allows a value such as the offending configuration or an HTTP
+// response to be carried with an error without defining a custom error type.
+// The message, Cause and kinds of err are unchanged. If err is nil, the result
+// will be nil.
+//
+// For example:
+//
+//	if resp.StatusCode != http.StatusOK {
+//	    return errors.WithValue(errors.Errorf("unexpected status %d", resp.StatusCode), resp)
+//	}
+func WithValue[T any](err error, v T) error {
+	if err == nil {
+		return nil
+	}
+	newErr := &Err{
+		previous:   err,
+		cause:      Cause(err),
+		attachment: valueAttachment[T]{value: v},
+	}
+	newErr.SetLocation(1)
+	return newErr
+}
+
+// Value returns the value of type T attached to err by the outermost call to
+// WithValue[T] in its chain. As with context values, a value attached by an
+// outer error shadows any value of the same type attached by an inner one.
+// Values are found by the type they were attached with, not by their dynamic
+// type: if T is an interface type, only values attached as T are returned, and
+// these may be nil. If there is no such value, the zero value of T is returned
+// with false.
+//
+// For example:
+//
+//	if resp, ok := errors.Value[*http.Response](err); ok {
+//	    fmt.Println(resp.Status)
+//	}
+func Value[T any](err error) (T, bool) {
+	attachment, ok := attached[valueAttachment[T]](err)
+	return attachment.value, ok
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/errors"
+)
+
+type valuesSuite struct{}
+
+var _ = gc.Suite(&valuesSuite{})
+
+type retryBudget struct {
+	remaining int
+}
+
+func (*valuesSuite) TestWithValueNil(c *gc.C) {
+	c.Assert(errors.WithValue(nil, 42), gc.IsNil)
+}
+
+func (*valuesSuite) TestValue(c *gc.C) {
+	first := errors.Timeoutf("connecting")
+	err := errors.WithValue(first, &retryBudget{remaining: 3})
+	loc := errorLocationValue(c)
+	err = errors.Annotate(err, "cannot deploy")
+	err = fmt.Errorf("wrapped: %w", err)
+
+	c.Assert(err.Error(), gc.Equals, "wrapped: cannot deploy: connecting timeout")
+	c.Assert(errors.Is(err, errors.Timeout), gc.Equals, true)
+	c.Assert(errors.ErrorStack(errors.Unwrap(err)), Contains, loc)
+
+	budget, ok := errors.Value[*retryBudget](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(budget.remaining, gc.Equals, 3)
+
+	_, ok = errors.Value[retryBudget](err)
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (*valuesSuite) TestValueShadowing(c *gc.C) {
+	err := errors.WithValue(errors.New("boom"), "inner")
+	err = errors.WithValue(err, 42)
+	err = errors.Trace(err)
+	err = errors.WithValue(err, "outer")
+
+	s, ok := errors.Value[string](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(s, gc.Equals, "outer")
+
+	n, ok := errors.Value[int](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(n, gc.Equals, 42)
+
+	stringer, ok := errors.Value[fmt.Stringer](err)
+	c.Assert(ok, gc.Equals, false)
+	c.Assert(stringer, gc.IsNil)
+}
+
+func (*valuesSuite) TestValueIgnoresOtherAttachments(c *gc.C) {
+	err := errors.With(errors.New("boom"), "unit", "mysql/0")
+	err = errors.WithErrorInfo(err, errors.ErrorInfo{Reason: "BOOM"})
+	_, ok := errors.Value[interface{}](err)
+	c.Assert(ok, gc.Equals, false)
+	_, ok = errors.Value[errors.ErrorInfo](err)
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (*valuesSuite) TestValueKeyedByType(c *gc.C) {
+	err := errors.WithValue(errors.New("boom"), &retryBudget{remaining: 3})
+	err = errors.WithValue[error](err, nil)
+	err = errors.WithValue[fmt.Stringer](err, time.Second)
+
+	// A nil interface value is found by its type.
+	value, ok := errors.Value[error](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(value, gc.IsNil)
+
+	// A value is only found by the type it was attached with.
+	_, ok = errors.Value[interface{}](err)
+	c.Assert(ok, gc.Equals, false)
+	_, ok = errors.Value[time.Duration](err)
+	c.Assert(ok, gc.Equals, false)
+	budget, ok := errors.Value[*retryBudget](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(budget.remaining, gc.Equals, 3)
+	stringer, ok := errors.Value[fmt.Stringer](err)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(stringer, gc.Equals, time.Second)
+}